@@ -0,0 +1,65 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+)
+
+// Backend fetches the raw JSON body for a request path relative to the API
+// root, such as "pokemon/25" or "nature/?limit=20". It returns ErrNotFound
+// when the resource does not exist.
+type Backend interface {
+	Fetch(ctx context.Context, path string) ([]byte, error)
+}
+
+// OfflineMode selects how a Client combines a snapshot with the network.
+type OfflineMode int
+
+const (
+	// OfflineOnly serves every request from the snapshot.
+	OfflineOnly OfflineMode = iota
+	// OfflineFirst serves from the snapshot, falling back to the network for
+	// resources the snapshot does not have.
+	OfflineFirst
+	// NetworkFirst serves from the network, falling back to the snapshot when
+	// the network fails. A not found response from the network is final.
+	NetworkFirst
+)
+
+// UseSnapshot makes the client serve requests from snapshot according to
+// mode. The network side uses the client's HTTPClient and Endpoint.
+func (c *Client) UseSnapshot(snapshot Backend, mode OfflineMode) {
+	network := networkBackend{client: c}
+	switch mode {
+	case OfflineFirst:
+		c.Backend = fallbackBackend{primary: snapshot, fallback: network, shouldFallback: func(error) bool { return true }}
+	case NetworkFirst:
+		c.Backend = fallbackBackend{primary: network, fallback: snapshot, shouldFallback: func(err error) bool { return !errors.Is(err, ErrNotFound) }}
+	default:
+		c.Backend = snapshot
+	}
+}
+
+// networkBackend fetches over HTTP using the client's HTTPClient and Endpoint.
+type networkBackend struct {
+	client *Client
+}
+
+func (b networkBackend) Fetch(ctx context.Context, path string) ([]byte, error) {
+	return fetchHTTP(ctx, b.client, path)
+}
+
+// fallbackBackend tries primary, then fallback when shouldFallback accepts the error.
+type fallbackBackend struct {
+	primary        Backend
+	fallback       Backend
+	shouldFallback func(error) bool
+}
+
+func (b fallbackBackend) Fetch(ctx context.Context, path string) ([]byte, error) {
+	body, err := b.primary.Fetch(ctx, path)
+	if err == nil || ctx.Err() != nil || !b.shouldFallback(err) {
+		return body, err
+	}
+	return b.fallback.Fetch(ctx, path)
+}
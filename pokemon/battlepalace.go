@@ -0,0 +1,75 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// MoveBattleStyle represents a style of move (attack, defense or support) a
+// Pokémon may favour in the Battle Palace.
+type MoveBattleStyle struct {
+	ID    int                        `json:"id"`
+	Name  string                     `json:"name"`
+	Names LocalizedNames             `json:"names"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// GetMoveBattleStyleOpts contains options for GetMoveBattleStyle function.
+type GetMoveBattleStyleOpts struct {
+	// ID is the ID of the MoveBattleStyle to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the MoveBattleStyle to retrieve.
+	Name string
+}
+
+// BattlePalaceStyles holds the probability, between 0 and 1, of a nature
+// selecting each move battle style, keyed by style name.
+type BattlePalaceStyles struct {
+	HighHP map[string]float64
+	LowHP  map[string]float64
+}
+
+// GetMoveBattleStyle gets a move battle style by ID or Name.
+func (c *Client) GetMoveBattleStyle(ctx context.Context, opts GetMoveBattleStyleOpts) (MoveBattleStyle, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return MoveBattleStyle{}, err
+	}
+	return Get[MoveBattleStyle](ctx, c, lookupValue)
+}
+
+// PredictBattlePalaceStyles predicts how likely a nature is to select each
+// move battle style in the Battle Palace, above and below half HP.
+func PredictBattlePalaceStyles(nature Nature) BattlePalaceStyles {
+	styles := BattlePalaceStyles{
+		HighHP: make(map[string]float64),
+		LowHP:  make(map[string]float64),
+	}
+	var highTotal, lowTotal int
+	for _, pref := range nature.MoveBattleStylePreferences {
+		highTotal += pref.HighHPPreference
+		lowTotal += pref.LowHPPreference
+	}
+	for _, pref := range nature.MoveBattleStylePreferences {
+		styles.HighHP[pref.MoveBattleStyle.Name] = ratio(pref.HighHPPreference, highTotal)
+		styles.LowHP[pref.MoveBattleStyle.Name] = ratio(pref.LowHPPreference, lowTotal)
+	}
+	return styles
+}
+
+// At returns the style probabilities for a Pokémon with the given HP. The
+// Battle Palace switches to the low HP preferences below half of max HP.
+func (s BattlePalaceStyles) At(currentHP, maxHP int) map[string]float64 {
+	if currentHP*2 < maxHP {
+		return s.LowHP
+	}
+	return s.HighHP
+}
+
+// helper function to divide preferences without dividing by zero
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
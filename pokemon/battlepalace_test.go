@@ -0,0 +1,63 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMoveBattleStyle(t *testing.T) {
+	expected := MoveBattleStyle{
+		ID:   1,
+		Name: "attack",
+		Names: []NatureName{
+			{Name: "Attack", Language: NamedURL{Name: "en", URL: "/language/9"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/move-battle-style/attack" {
+			http.Error(w, "Move battle style not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	style, err := client.GetMoveBattleStyle(context.Background(), GetMoveBattleStyleOpts{Name: "Attack"})
+	require.NoError(t, err)
+	require.Equal(t, expected, style)
+
+	_, err = client.GetMoveBattleStyle(context.Background(), GetMoveBattleStyleOpts{Name: "unknown"})
+	require.Error(t, err)
+}
+
+func TestPredictBattlePalaceStyles(t *testing.T) {
+	nature := Nature{
+		Name: "lonely",
+		MoveBattleStylePreferences: []MoveBattleStylePreference{
+			{LowHPPreference: 70, HighHPPreference: 58, MoveBattleStyle: NamedURL{Name: "attack"}},
+			{LowHPPreference: 15, HighHPPreference: 37, MoveBattleStyle: NamedURL{Name: "defense"}},
+			{LowHPPreference: 15, HighHPPreference: 5, MoveBattleStyle: NamedURL{Name: "support"}},
+		},
+	}
+
+	styles := PredictBattlePalaceStyles(nature)
+
+	require.InDelta(t, 0.58, styles.HighHP["attack"], 1e-9)
+	require.InDelta(t, 0.37, styles.HighHP["defense"], 1e-9)
+	require.InDelta(t, 0.05, styles.HighHP["support"], 1e-9)
+	require.InDelta(t, 0.70, styles.LowHP["attack"], 1e-9)
+
+	require.Equal(t, styles.HighHP, styles.At(50, 100))
+	require.Equal(t, styles.LowHP, styles.At(49, 100))
+
+	empty := PredictBattlePalaceStyles(Nature{})
+	require.Empty(t, empty.HighHP)
+	require.Empty(t, empty.LowHP)
+}
@@ -0,0 +1,188 @@
+package pokemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores responses by URL for Client.
+type Cache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry is a cached response body with the validators needed to
+// revalidate it with a conditional request.
+type CacheEntry struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+	StoredAt     time.Time
+	// Expires is when the entry becomes stale and must be revalidated.
+	Expires time.Time
+}
+
+// Fresh reports whether the entry can be used at now without revalidating.
+func (e CacheEntry) Fresh(now time.Time) bool {
+	return now.Before(e.Expires)
+}
+
+// helper function to add If-None-Match and If-Modified-Since for the entry's validators
+func (e CacheEntry) setValidators(header http.Header) {
+	if e.ETag != "" {
+		header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("If-Modified-Since", e.LastModified)
+	}
+}
+
+// helper function to refresh an entry after a 304 Not Modified response
+func (e CacheEntry) revalidated(header http.Header, ttl time.Duration, now time.Time) CacheEntry {
+	if etag := header.Get("ETag"); etag != "" {
+		e.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		e.LastModified = lastModified
+	}
+	e.StoredAt = now
+	e.Expires = now.Add(freshness(header, ttl))
+	return e
+}
+
+func newCacheEntry(body []byte, header http.Header, ttl time.Duration, now time.Time) CacheEntry {
+	return CacheEntry{
+		Body:         body,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		StoredAt:     now,
+		Expires:      now.Add(freshness(header, ttl)),
+	}
+}
+
+// helper function to get how long a response stays fresh, preferring Cache-Control max-age
+func freshness(header http.Header, ttl time.Duration) time.Duration {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(strings.ToLower(directive))
+		if directive == "no-cache" {
+			return 0
+		}
+		if value, ok := strings.CutPrefix(directive, "max-age="); ok {
+			if seconds, err := strconv.Atoi(value); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return ttl
+}
+
+// helper function to check the response allows storing
+func cacheable(header http.Header) bool {
+	return !strings.Contains(strings.ToLower(header.Get("Cache-Control")), "no-store")
+}
+
+// MemoryCache is a Cache held in memory. It is safe for concurrent use.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]CacheEntry
+}
+
+// NewMemoryCache returns an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]CacheEntry)}
+}
+
+// Get returns the entry for key.
+func (m *MemoryCache) Get(key string) (CacheEntry, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entry, ok := m.entries[key]
+	return entry, ok
+}
+
+// Set stores the entry for key.
+func (m *MemoryCache) Set(key string, entry CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = entry
+}
+
+// DirCache is a Cache stored in a directory, so entries survive restarts,
+// e.g. to play cries on a device that is often offline. Each entry is a body
+// file and a metadata file named after the checksum of its key. Entries that
+// cannot be read or written are treated as missing.
+type DirCache struct {
+	Dir string
+}
+
+// NewDirCache returns a DirCache storing entries in dir.
+func NewDirCache(dir string) *DirCache {
+	return &DirCache{Dir: dir}
+}
+
+// dirCacheMeta is the metadata file of a DirCache entry.
+type dirCacheMeta struct {
+	Key          string    `json:"key"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+	Expires      time.Time `json:"expires"`
+	SHA256       string    `json:"sha256"`
+}
+
+// Get returns the entry for key.
+func (d *DirCache) Get(key string) (CacheEntry, bool) {
+	base := d.path(key)
+	data, err := os.ReadFile(base + ".json")
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	var meta dirCacheMeta
+	if err := json.Unmarshal(data, &meta); err != nil || meta.Key != key {
+		return CacheEntry{}, false
+	}
+	body, err := os.ReadFile(base + ".body")
+	if err != nil || checksum(body) != meta.SHA256 {
+		return CacheEntry{}, false
+	}
+	return CacheEntry{
+		Body:         body,
+		ETag:         meta.ETag,
+		LastModified: meta.LastModified,
+		StoredAt:     meta.StoredAt,
+		Expires:      meta.Expires,
+	}, true
+}
+
+// Set stores the entry for key. The body is written before the metadata, so
+// an interrupted write leaves the previous entry or none.
+func (d *DirCache) Set(key string, entry CacheEntry) {
+	base := d.path(key)
+	meta, err := json.Marshal(dirCacheMeta{
+		Key:          key,
+		ETag:         entry.ETag,
+		LastModified: entry.LastModified,
+		StoredAt:     entry.StoredAt,
+		Expires:      entry.Expires,
+		SHA256:       checksum(entry.Body),
+	})
+	if err != nil {
+		return
+	}
+	if err := writeFileAtomic(base+".body", entry.Body); err != nil {
+		return
+	}
+	_ = writeFileAtomic(base+".json", meta)
+}
+
+// helper function to get the path of an entry's files without their extension
+func (d *DirCache) path(key string) string {
+	sum := checksum([]byte(key))
+	return filepath.Join(d.Dir, sum[:2], sum)
+}
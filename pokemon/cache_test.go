@@ -0,0 +1,160 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockValidatorServer initializes a new httptest.Server that serves a
+// nature with an ETag and Last-Modified, answering conditional requests
+// with 304 until the nature changes.
+func mockValidatorServer(header http.Header, version *atomic.Int32, full, notModified *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+		etag := `"v` + strconv.Itoa(int(version.Load())) + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Sat, 17 Oct 2026 00:00:00 GMT")
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		json.NewEncoder(w).Encode(Nature{ID: int(version.Load()), Name: "hardy"})
+	}))
+}
+
+func TestConditionalRequests(t *testing.T) {
+	var version, full, notModified atomic.Int32
+	version.Store(1)
+	server := mockValidatorServer(http.Header{}, &version, &full, &notModified)
+	defer server.Close()
+
+	cache := NewMemoryCache()
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL, Cache: cache}
+
+	nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+	require.NoError(t, err)
+	require.Equal(t, 1, nature.ID)
+
+	entry, ok := cache.Get(server.URL + "/nature/hardy")
+	require.True(t, ok)
+	require.Equal(t, `"v1"`, entry.ETag)
+	require.Equal(t, "Sat, 17 Oct 2026 00:00:00 GMT", entry.LastModified)
+
+	t.Run("Stale entry is revalidated and served from cache on 304", func(t *testing.T) {
+		nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+		require.Equal(t, 1, nature.ID)
+		require.Equal(t, int32(1), full.Load())
+		require.Equal(t, int32(1), notModified.Load())
+	})
+
+	t.Run("Changed resource is downloaded again", func(t *testing.T) {
+		version.Store(2)
+		nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+		require.Equal(t, 2, nature.ID)
+		require.Equal(t, int32(2), full.Load())
+
+		entry, _ := cache.Get(server.URL + "/nature/hardy")
+		require.Equal(t, `"v2"`, entry.ETag)
+	})
+}
+
+func TestCacheFreshness(t *testing.T) {
+	tests := []struct {
+		scenario         string
+		header           http.Header
+		ttl              time.Duration
+		expectedRequests int32
+	}{
+		{
+			scenario:         "Fresh for max-age without a request",
+			header:           http.Header{"Cache-Control": {"public, max-age=3600"}},
+			expectedRequests: 1,
+		},
+		{
+			scenario:         "Fresh for CacheTTL without max-age",
+			header:           http.Header{},
+			ttl:              time.Hour,
+			expectedRequests: 1,
+		},
+		{
+			scenario:         "no-cache always revalidates",
+			header:           http.Header{"Cache-Control": {"no-cache"}},
+			ttl:              time.Hour,
+			expectedRequests: 2,
+		},
+		{
+			scenario:         "no-store is never cached",
+			header:           http.Header{"Cache-Control": {"no-store"}},
+			ttl:              time.Hour,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			var version, full, notModified atomic.Int32
+			version.Store(1)
+			server := mockValidatorServer(tt.header, &version, &full, &notModified)
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), Endpoint: server.URL, Cache: NewMemoryCache(), CacheTTL: tt.ttl}
+			for i := 0; i < 2; i++ {
+				_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedRequests, full.Load()+notModified.Load())
+		})
+	}
+}
+
+func TestDirCache(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Second)
+	entry := CacheEntry{Body: []byte("OggS"), ETag: `"v1"`, StoredAt: now, Expires: now.Add(time.Hour)}
+	NewDirCache(dir).Set("https://example.test/cries/25.ogg", entry)
+
+	t.Run("Survives a new cache", func(t *testing.T) {
+		got, ok := NewDirCache(dir).Get("https://example.test/cries/25.ogg")
+		require.True(t, ok)
+		require.Equal(t, entry.Body, got.Body)
+		require.Equal(t, entry.ETag, got.ETag)
+		require.True(t, entry.Expires.Equal(got.Expires))
+	})
+
+	t.Run("Missing key", func(t *testing.T) {
+		_, ok := NewDirCache(dir).Get("https://example.test/cries/26.ogg")
+		require.False(t, ok)
+	})
+
+	t.Run("Corrupt body", func(t *testing.T) {
+		cache := NewDirCache(dir)
+		require.NoError(t, os.WriteFile(cache.path("https://example.test/cries/25.ogg")+".body", []byte("junk"), 0o644))
+		_, ok := cache.Get("https://example.test/cries/25.ogg")
+		require.False(t, ok)
+	})
+
+	t.Run("Unwritable directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, nil, 0o644))
+		cache := NewDirCache(file)
+		cache.Set("key", entry)
+		_, ok := cache.Get("key")
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,209 @@
+// Package cassette provides an http.RoundTripper that records HTTP
+// interactions to a cassette file and replays them later, so tests written
+// against a live API can run without network access.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// Replay serves every request from the cassette and fails requests it
+	// has no recording for.
+	Replay Mode = iota
+	// Record performs every request for real and saves it to the cassette on Stop.
+	Record
+)
+
+// ErrNoInteraction is returned when replaying a request that was not recorded.
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+// Request is the recorded part of an HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// Response is the recorded part of an HTTP response. Body is saved as
+// base64, so binary bodies such as images are recorded unchanged.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body"`
+}
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Redactor edits an interaction before it is saved, e.g. to remove secrets.
+// Redactors are also applied to incoming requests before matching them in
+// Replay mode, so a redacted URL still matches.
+type Redactor func(*Interaction)
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+// Requests are matched on method and URL; repeated requests replay the
+// matching interactions in the order they were recorded.
+type Recorder struct {
+	// Transport performs the requests when recording. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path      string
+	mode      Mode
+	redactors []Redactor
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	replayed     map[*Interaction]bool
+}
+
+// New returns a Recorder for the cassette at path. In Replay mode the
+// cassette must exist; the error wraps fs.ErrNotExist if it does not.
+func New(path string, mode Mode, redactors ...Redactor) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		redactors: redactors,
+		replayed:  make(map[*Interaction]bool),
+	}
+	if mode == Record {
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("error reading cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Client returns an http.Client that sends its requests through the Recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records or replays req depending on the Recorder's mode.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == Record {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// Stop saves the cassette when recording. It does nothing when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != Record {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := &Interaction{
+		Request: Request{Method: req.Method, URL: req.URL.String(), Header: req.Header.Clone()},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       body,
+		},
+	}
+	for _, redact := range r.redactors {
+		redact(interaction)
+	}
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	incoming := &Interaction{Request: Request{Method: req.Method, URL: req.URL.String(), Header: req.Header.Clone()}}
+	for _, redact := range r.redactors {
+		redact(incoming)
+	}
+
+	r.mu.Lock()
+	var match *Interaction
+	for _, interaction := range r.interactions {
+		if interaction.Request.Method != incoming.Request.Method || interaction.Request.URL != incoming.Request.URL {
+			continue
+		}
+		match = interaction
+		if !r.replayed[interaction] {
+			break
+		}
+	}
+	if match != nil {
+		r.replayed[match] = true
+	}
+	r.mu.Unlock()
+
+	if match == nil {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, incoming.Request.URL)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", match.Response.StatusCode, http.StatusText(match.Response.StatusCode)),
+		StatusCode:    match.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        match.Response.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(match.Response.Body)),
+		ContentLength: int64(len(match.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// RedactHeaders returns a Redactor that replaces the values of the named
+// request and response headers with "REDACTED".
+func RedactHeaders(names ...string) Redactor {
+	return func(i *Interaction) {
+		for _, name := range names {
+			for _, header := range []http.Header{i.Request.Header, i.Response.Header} {
+				if header.Get(name) != "" {
+					header.Set(name, "REDACTED")
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package cassette
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, client *http.Client, rawURL string) (int, string, http.Header) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body), resp.Header
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Set-Cookie", "session=abc")
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"call":` + strconv.Itoa(calls) + `}`))
+	}))
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	dropToken := func(i *Interaction) {
+		u, err := url.Parse(i.Request.URL)
+		require.NoError(t, err)
+		query := u.Query()
+		query.Del("token")
+		u.RawQuery = query.Encode()
+		i.Request.URL = u.String()
+	}
+
+	recorder, err := New(path, Record, RedactHeaders("Authorization", "Set-Cookie"), dropToken)
+	require.NoError(t, err)
+	client := recorder.Client()
+
+	status, body, header := get(t, client, server.URL+"/pokemon/25?token=abc")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, `{"call":1}`, body)
+	require.Equal(t, "session=abc", header.Get("Set-Cookie"), "recording returns the real response")
+	_, body, _ = get(t, client, server.URL+"/pokemon/25?token=abc")
+	require.Equal(t, `{"call":2}`, body)
+	status, _, _ = get(t, client, server.URL+"/missing")
+	require.Equal(t, http.StatusNotFound, status)
+	require.NoError(t, recorder.Stop())
+	server.Close()
+
+	replayer, err := New(path, Replay, RedactHeaders("Authorization", "Set-Cookie"), dropToken)
+	require.NoError(t, err)
+	client = replayer.Client()
+
+	t.Run("Replays in recorded order, then repeats the last", func(t *testing.T) {
+		_, body, header := get(t, client, server.URL+"/pokemon/25?token=other")
+		require.Equal(t, `{"call":1}`, body)
+		require.Equal(t, "REDACTED", header.Get("Set-Cookie"))
+		_, body, _ = get(t, client, server.URL+"/pokemon/25?token=other")
+		require.Equal(t, `{"call":2}`, body)
+		_, body, _ = get(t, client, server.URL+"/pokemon/25?token=other")
+		require.Equal(t, `{"call":2}`, body)
+	})
+
+	t.Run("Replays status codes", func(t *testing.T) {
+		status, _, _ := get(t, client, server.URL+"/missing")
+		require.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("Unrecorded request", func(t *testing.T) {
+		_, err := client.Get(server.URL + "/pokemon/1")
+		require.ErrorIs(t, err, ErrNoInteraction)
+	})
+
+	t.Run("Secrets are not saved", func(t *testing.T) {
+		for _, interaction := range replayer.interactions {
+			require.NotContains(t, interaction.Request.URL, "token")
+			require.Equal(t, "REDACTED", interaction.Request.Header.Get("Authorization"))
+		}
+	})
+}
+
+func TestBinaryBodies(t *testing.T) {
+	// A PNG signature, which is not valid UTF-8
+	png := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0xff, 0x00}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(png)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := New(path, Record)
+	require.NoError(t, err)
+	_, body, _ := get(t, recorder.Client(), server.URL+"/sprites/25.png")
+	require.Equal(t, string(png), body)
+	require.NoError(t, recorder.Stop())
+
+	replayer, err := New(path, Replay)
+	require.NoError(t, err)
+	_, body, _ = get(t, replayer.Client(), server.URL+"/sprites/25.png")
+	require.Equal(t, string(png), body, "the body is replayed byte for byte")
+}
+
+func TestMissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), Replay)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,50 @@
+//go:build e2e
+// +build e2e
+
+package pokemon
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/ashgodfrey/pokemon-api/pokemon/cassette"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	record  = flag.Bool("record", false, "record the e2e cassettes in testdata from the live API")
+	offline = flag.Bool("offline", false, "fail e2e tests without a cassette instead of calling the live API")
+)
+
+// useCassette points DefaultClient at the public API for the rest of the
+// test. Responses are replayed from testdata/cassettes, or recorded there
+// from the live API with -record. Tests without a cassette call the live
+// API, or fail with -offline so CI stays hermetic.
+func useCassette(t *testing.T) {
+	t.Helper()
+	original := *DefaultClient
+	t.Cleanup(func() { *DefaultClient = original })
+	DefaultClient.Endpoint = "https://pokeapi.co/api/v2/"
+	DefaultClient.Backend = nil
+	mode := cassette.Replay
+	if *record {
+		mode = cassette.Record
+	}
+	recorder, err := cassette.New(filepath.Join("testdata", "cassettes", t.Name()+".json"), mode,
+		cassette.RedactHeaders("Set-Cookie", "Cf-Ray", "Report-To", "Nel"))
+	if errors.Is(err, fs.ErrNotExist) {
+		if *offline {
+			t.Fatalf("no cassette for %s; run go test -tags=e2e -run %s -record with network access to record it", t.Name(), t.Name())
+		}
+		t.Logf("no cassette for %s, calling the live API", t.Name())
+		DefaultClient.HTTPClient = http.DefaultClient
+		return
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, recorder.Stop()) })
+	DefaultClient.HTTPClient = recorder.Client()
+}
@@ -0,0 +1,100 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MaxIV is the highest value an individual value can take.
+const MaxIV = 31
+
+type CharacteristicDescription struct {
+	Description string   `json:"description"`
+	Language    NamedURL `json:"language"`
+}
+
+// CharacteristicDescriptions is the list of texts a characteristic has across languages.
+type CharacteristicDescriptions []CharacteristicDescription
+
+// Characteristic represents the text shown on a Pokémon's summary based on its highest IV.
+type Characteristic struct {
+	ID             int                        `json:"id"`
+	GeneModulo     int                        `json:"gene_modulo"`
+	PossibleValues []int                      `json:"possible_values"`
+	HighestStat    NamedURL                   `json:"highest_stat"`
+	Descriptions   CharacteristicDescriptions `json:"descriptions"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+// GetCharacteristicOpts contains options for GetCharacteristic function.
+type GetCharacteristicOpts struct {
+	// ID is the ID of the Characteristic to retrieve. Characteristics have no name.
+	ID int
+}
+
+// DescriptionIn returns the description in lang, falling back like LocalizedNames.NameIn.
+func (d CharacteristicDescriptions) DescriptionIn(lang string, fallback ...string) string {
+	return pickLocalized(d, func(entry CharacteristicDescription) (string, string) {
+		return entry.Language.Name, entry.Description
+	}, lang, fallback)
+}
+
+// GetCharacteristic gets a characteristic by ID.
+func (c *Client) GetCharacteristic(ctx context.Context, opts GetCharacteristicOpts) (Characteristic, error) {
+	lookupValue, err := getLookupValue(opts.ID, "")
+	if err != nil {
+		return Characteristic{}, err
+	}
+	return Get[Characteristic](ctx, c, lookupValue)
+}
+
+// CharacteristicID returns the ID of the characteristic a Pokémon with the
+// given IVs displays. Ties for the highest IV are broken in the order HP,
+// Attack, Defense, Speed, Special Attack, Special Defense.
+func CharacteristicID(ivs StatSet) (int, error) {
+	// Characteristic IDs are grouped by gene modulo, and within each group
+	// ordered HP, Attack, Defense, Special Attack, Special Defense, Speed.
+	candidates := []struct {
+		value  int
+		offset int
+	}{
+		{ivs.HP, 0},
+		{ivs.Attack, 1},
+		{ivs.Defense, 2},
+		{ivs.Speed, 5},
+		{ivs.SpecialAttack, 3},
+		{ivs.SpecialDefense, 4},
+	}
+
+	best := candidates[0]
+	for _, candidate := range candidates {
+		if candidate.value < 0 || candidate.value > MaxIV {
+			return 0, fmt.Errorf("IV %d is out of range 0-%d", candidate.value, MaxIV)
+		}
+		if candidate.value > best.value {
+			best = candidate
+		}
+	}
+	return 1 + best.offset + 6*(best.value%5), nil
+}
+
+// GetCharacteristicText returns the characteristic text, in the given
+// language or the first fallback language available, a Pokémon with the
+// given IVs displays.
+func (c *Client) GetCharacteristicText(ctx context.Context, ivs StatSet, language string, fallback ...string) (string, error) {
+	id, err := CharacteristicID(ivs)
+	if err != nil {
+		return "", err
+	}
+	characteristic, err := c.GetCharacteristic(ctx, GetCharacteristicOpts{ID: id})
+	if err != nil {
+		return "", err
+	}
+	text := characteristic.Descriptions.DescriptionIn(language, fallback...)
+	if text == "" {
+		return "", errors.New("no description for language " + language)
+	}
+	return text, nil
+}
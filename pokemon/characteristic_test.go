@@ -0,0 +1,96 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharacteristicID(t *testing.T) {
+	tests := []struct {
+		scenario string
+		ivs      StatSet
+		expected int
+		err      bool
+	}{
+		{
+			scenario: "Highest HP with modulo 0",
+			ivs:      StatSet{HP: 30, Attack: 12, Defense: 3, SpecialAttack: 4, SpecialDefense: 5, Speed: 6},
+			expected: 1,
+		},
+		{
+			scenario: "Highest Speed with modulo 1",
+			ivs:      StatSet{HP: 1, Attack: 2, Defense: 3, SpecialAttack: 4, SpecialDefense: 5, Speed: 31},
+			expected: 12,
+		},
+		{
+			scenario: "Highest Special Defense with modulo 4",
+			ivs:      StatSet{SpecialDefense: 29},
+			expected: 29,
+		},
+		{
+			scenario: "Tie between Speed and Special Attack favours Speed",
+			ivs:      StatSet{SpecialAttack: 20, Speed: 20},
+			expected: 6,
+		},
+		{
+			scenario: "Tie between HP and Attack favours HP",
+			ivs:      StatSet{HP: 31, Attack: 31},
+			expected: 7,
+		},
+		{
+			scenario: "IV out of range",
+			ivs:      StatSet{Attack: 32},
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			id, err := CharacteristicID(tt.ivs)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestGetCharacteristicText(t *testing.T) {
+	characteristic := Characteristic{
+		ID:             12,
+		GeneModulo:     1,
+		PossibleValues: []int{1, 6, 11, 16, 21, 26, 31},
+		HighestStat:    NamedURL{Name: "speed", URL: "/stat/6"},
+		Descriptions: []CharacteristicDescription{
+			{Description: "Alert to sounds", Language: NamedURL{Name: "en", URL: "/language/9"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/characteristic/12" {
+			http.Error(w, "Characteristic not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(characteristic)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	fetched, err := client.GetCharacteristic(context.Background(), GetCharacteristicOpts{ID: 12})
+	require.NoError(t, err)
+	require.Equal(t, characteristic, fetched)
+
+	text, err := client.GetCharacteristicText(context.Background(), StatSet{Speed: 31}, "en")
+	require.NoError(t, err)
+	require.Equal(t, "Alert to sounds", text)
+
+	_, err = client.GetCharacteristicText(context.Background(), StatSet{Speed: 31}, "fr")
+	require.Error(t, err)
+}
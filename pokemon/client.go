@@ -6,22 +6,66 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Client struct {
 	HTTPClient *http.Client
 	Endpoint   string
+	// Backend serves requests instead of HTTPClient and Endpoint when set,
+	// e.g. to read from an offline snapshot.
+	Backend Backend
+	// Cache stores responses so they can be revalidated with conditional
+	// requests instead of downloaded again. Nil disables caching.
+	Cache Cache
+	// CacheTTL is how long a cached response is used without revalidating
+	// when the response has no Cache-Control max-age.
+	CacheTTL time.Duration
+	// Middleware wraps every HTTP request the client makes. The first
+	// middleware is the outermost.
+	Middleware []Middleware
+	// Logger receives structured events for requests, cache hits and decode
+	// failures. Nil disables logging.
+	Logger *slog.Logger
+	// Hooks receives instrumentation events for calls, requests and cache
+	// lookups, e.g. to record traces and metrics. Nil disables them.
+	Hooks Hooks
+	// MaxBodySize is the largest response body in bytes the client reads
+	// before failing with ErrBodyTooLarge. Zero uses DefaultMaxBodySize and a
+	// negative value disables the limit.
+	MaxBodySize int64
+	// DecodeMode controls how fields the models don't know about are handled.
+	DecodeMode DecodeMode
+	// ReferenceBase is the base URL of resource references in responses,
+	// rewritten to Endpoint so references followed with the client stay on
+	// the same server, e.g. PublicEndpoint for a mirror that returns
+	// pokeapi.co URLs. Empty disables rewriting.
+	ReferenceBase string
+
+	// configErr is returned by every request when the client was created
+	// from an invalid configuration.
+	configErr error
 }
 
+// ErrNotFound is returned when the requested resource does not exist.
+var ErrNotFound = errors.New("not found")
+
 type NamedURL struct {
 	Name string `json:"name"`
 	URL  string `json:"url"`
 }
 
+// APIResource is a reference to a resource that has no name, such as a Characteristic.
+type APIResource struct {
+	URL string `json:"url"`
+}
+
 type PokeathlonStatChange struct {
 	MaxChange      int      `json:"max_change"`
 	PokeathlonStat NamedURL `json:"pokeathlon_stat"`
@@ -33,11 +77,6 @@ type MoveBattleStylePreference struct {
 	MoveBattleStyle  NamedURL `json:"move_battle_style"`
 }
 
-type NatureName struct {
-	Name     string   `json:"name"`
-	Language NamedURL `json:"language"`
-}
-
 // Nature represents the details of a specific nature as defined by the Pokémon pokemon.
 type Nature struct {
 	ID                         int                         `json:"id"`
@@ -48,7 +87,8 @@ type Nature struct {
 	HatesFlavor                NamedURL                    `json:"hates_flavor"`
 	PokeathlonStatChanges      []PokeathlonStatChange      `json:"pokeathlon_stat_changes"`
 	MoveBattleStylePreferences []MoveBattleStylePreference `json:"move_battle_style_preferences"`
-	Names                      []NatureName                `json:"names"`
+	Names                      LocalizedNames              `json:"names"`
+	Extra                      map[string]json.RawMessage  `json:"-"`
 }
 
 type MoveEffect struct {
@@ -66,27 +106,34 @@ type AffectingNatures struct {
 	Decrease []NamedURL `json:"decrease"`
 }
 
-type Characteristic struct {
-	URL string `json:"url"`
-}
-
 // Stat represents the details of a specific stat for a Pokémon as defined by the Pokémon pokemon.
 type Stat struct {
-	ID               int              `json:"id"`
-	Name             string           `json:"name"`
-	GameIndex        int              `json:"game_index"`
-	IsBattleOnly     bool             `json:"is_battle_only"`
-	AffectingMoves   AffectingMoves   `json:"affecting_moves"`
-	AffectingNatures AffectingNatures `json:"affecting_natures"`
-	Characteristics  []Characteristic `json:"characteristics"`
-	MoveDamageClass  NamedURL         `json:"move_damage_class"`
-	Names            []NatureName     `json:"names"`
+	ID               int                        `json:"id"`
+	Name             string                     `json:"name"`
+	GameIndex        int                        `json:"game_index"`
+	IsBattleOnly     bool                       `json:"is_battle_only"`
+	AffectingMoves   AffectingMoves             `json:"affecting_moves"`
+	AffectingNatures AffectingNatures           `json:"affecting_natures"`
+	Characteristics  []APIResource              `json:"characteristics"`
+	MoveDamageClass  NamedURL                   `json:"move_damage_class"`
+	Names            LocalizedNames             `json:"names"`
+	Extra            map[string]json.RawMessage `json:"-"`
 }
 
 type StatDetails struct {
 	BaseStat int      `json:"base_stat"`
 	Effort   int      `json:"effort"`
-	StatInfo NamedURL `json:"stat_info"`
+	StatInfo NamedURL `json:"stat"`
+}
+
+// StatSet holds one value per battle stat, such as a Pokémon's IVs.
+type StatSet struct {
+	HP             int
+	Attack         int
+	Defense        int
+	SpecialAttack  int
+	SpecialDefense int
+	Speed          int
 }
 
 // Pokemon represents the details of a Pokémon as defined by the Pokémon pokemon.
@@ -105,12 +152,15 @@ type Pokemon struct {
 	Version        NamedURL `json:"version"`
 	Item           NamedURL `json:"item"`
 	// LocationAreaEncounters will return an array if IncludeLocation is true, otherwise it will return a string URL.
-	LocationAreaEncounters EncountersData `json:"location_area_encounters"`
-	Move                   NamedURL       `json:"move"`
-	Species                NamedURL       `json:"species"`
-	StatDetails            []StatDetails  `json:"stats"`
-	Type                   NamedURL       `json:"type"`
-	Generation             NamedURL       `json:"generation"`
+	LocationAreaEncounters EncountersData             `json:"location_area_encounters"`
+	Move                   NamedURL                   `json:"move"`
+	Species                NamedURL                   `json:"species"`
+	Sprites                PokemonSprites             `json:"sprites"`
+	Cries                  PokemonCries               `json:"cries"`
+	StatDetails            []StatDetails              `json:"stats"`
+	Type                   NamedURL                   `json:"type"`
+	Generation             NamedURL                   `json:"generation"`
+	Extra                  map[string]json.RawMessage `json:"-"`
 }
 
 type LocationAreaEncounter struct {
@@ -168,11 +218,15 @@ type GetStatOpts struct {
 	Name string
 }
 
+// NewClient creates a client for the endpoint selected by EnvBaseURL,
+// defaulting to the public API. If EnvBaseURL is neither a profile nor an
+// absolute URL, every request made by the client fails with an error
+// describing the configuration.
 func NewClient() *Client {
-	return &Client{
-		HTTPClient: http.DefaultClient,
-		Endpoint:   "https://pokeapi.co/api/v2",
-	}
+	endpoint, err := endpointFromEnv()
+	c := newClientForEndpoint(endpoint)
+	c.configErr = err
+	return c
 }
 
 type EncountersData string
@@ -192,14 +246,16 @@ func getLookupValue(id int, name string) (string, error) {
 }
 
 // GetPokemon gets a Pokemon by ID or Name.
-func (c *Client) GetPokemon(ctx context.Context, opts GetPokemonOpts) (Pokemon, error) {
-	var pokemon Pokemon
+func (c *Client) GetPokemon(ctx context.Context, opts GetPokemonOpts) (pokemon Pokemon, err error) {
 	lookupValue, err := getLookupValue(opts.ID, opts.Name)
 	if err != nil {
-		return pokemon, err
+		return Pokemon{}, err
 	}
 
-	err = fetchAndUnmarshal(c, "pokemon/"+lookupValue, &pokemon)
+	ctx, end := c.hooks().StartCall(ctx, "pokemon.GetPokemon")
+	defer func() { end(err) }()
+
+	pokemon, err = get[Pokemon](ctx, c, lookupValue)
 	if err != nil {
 		return pokemon, err
 	}
@@ -207,7 +263,7 @@ func (c *Client) GetPokemon(ctx context.Context, opts GetPokemonOpts) (Pokemon,
 	// If IncludeLocation is true, make an additional API call for location details
 	if opts.IncludeLocation {
 		var locationDetails []LocationAreaEncounter
-		err = fetchAndUnmarshal(c, fmt.Sprintf("pokemon/%s/encounters", lookupValue), &locationDetails)
+		err = fetchAndUnmarshal(ctx, c, fmt.Sprintf("pokemon/%s/encounters", lookupValue), &locationDetails)
 		if err != nil {
 			return pokemon, err
 		}
@@ -222,56 +278,206 @@ func (c *Client) GetPokemon(ctx context.Context, opts GetPokemonOpts) (Pokemon,
 
 // GetNature gets a nature by ID or Name.
 func (c *Client) GetNature(ctx context.Context, opts GetNatureOpts) (Nature, error) {
-	var nature Nature
 	lookupValue, err := getLookupValue(opts.ID, opts.Name)
 	if err != nil {
-		return nature, err
+		return Nature{}, err
 	}
-	err = fetchAndUnmarshal(c, "nature/"+lookupValue, &nature)
-	return nature, err
+	return Get[Nature](ctx, c, lookupValue)
 }
 
 // GetStat gets a stat by ID or Name.
 func (c *Client) GetStat(ctx context.Context, opts GetStatOpts) (Stat, error) {
-	var stat Stat
 	lookupValue, err := getLookupValue(opts.ID, opts.Name)
 	if err != nil {
-		return stat, err
+		return Stat{}, err
 	}
-	err = fetchAndUnmarshal(c, "stat/"+lookupValue, &stat)
-	return stat, err
+	return Get[Stat](ctx, c, lookupValue)
 }
-func fetchAndUnmarshal[T any](c *Client, parameters string, dest *T) error {
-	// Parse the base URL and resolve the parameters
-	finalURL, err := url.Parse(c.Endpoint)
+
+func fetchAndUnmarshal[T any](ctx context.Context, c *Client, parameters string, dest *T) error {
+	if c.streams() {
+		return streamHTTP(ctx, c, parameters, dest)
+	}
+	body, err := fetchBody(ctx, c, parameters)
 	if err != nil {
 		return err
 	}
 
+	if err := decode(c, body, dest); err != nil {
+		logDecodeFailure(ctx, c, parameters, dest, err)
+		return err
+	}
+	return nil
+}
+
+// helper function to log a response for the parameters that could not be decoded into dest
+func logDecodeFailure[T any](ctx context.Context, c *Client, parameters string, dest *T, err error) {
+	c.logger().LogAttrs(ctx, slog.LevelError, "decode failed",
+		slog.String("path", parameters),
+		slog.String("type", fmt.Sprintf("%T", *dest)),
+		slog.String("error", err.Error()))
+}
+
+// helper function to check whether responses can be decoded straight off the
+// connection, which is when nothing else needs the raw body: there is no
+// backend, cache, reference rewrite or DecodeLenient
+func (c *Client) streams() bool {
+	return c.Backend == nil && c.Cache == nil && c.DecodeMode != DecodeLenient && !c.rewritesReferences()
+}
+
+// helper function to fetch the raw response body for the parameters
+func fetchBody(ctx context.Context, c *Client, parameters string) ([]byte, error) {
+	if c.Backend != nil {
+		return c.Backend.Fetch(ctx, parameters)
+	}
+	return fetchHTTP(ctx, c, parameters)
+}
+
+// helper function to resolve the parameters against the client's Endpoint
+func resolveURL(c *Client, parameters string) (string, error) {
+	finalURL, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return "", err
+	}
 	finalURL, err = finalURL.Parse(parameters)
 	if err != nil {
+		return "", err
+	}
+	return finalURL.String(), nil
+}
+
+// helper function to fetch the raw response body for the parameters over HTTP
+func fetchHTTP(ctx context.Context, c *Client, parameters string) ([]byte, error) {
+	key, err := resolveURL(c, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Share the result of an identical request already in flight
+	return inflight.do(ctx, flightKey{client: c, url: key}, func() ([]byte, error) {
+		return fetchURL(ctx, c, key, resourceKind(parameters))
+	})
+}
+
+// helper function to fetch the parameters over HTTP and decode the response
+// into dest as it is read. Callers that join a request in flight decode a
+// copy of the result, encoded from dest once the request is done.
+func streamHTTP[T any](ctx context.Context, c *Client, parameters string, dest *T) error {
+	rawURL, err := resolveURL(c, parameters)
+	if err != nil {
+		return err
+	}
+
+	key := flightKey{client: c, url: rawURL, dest: reflect.TypeOf(dest)}
+	body, led, err := inflight.call(ctx, key, func() ([]byte, error) {
+		return nil, streamURL(ctx, c, rawURL, parameters, dest)
+	}, func() ([]byte, error) {
+		return json.Marshal(dest)
+	})
+	if err != nil || led {
+		return err
+	}
+	if err := decode(c, body, dest); err != nil {
+		logDecodeFailure(ctx, c, parameters, dest, err)
 		return err
 	}
+	return nil
+}
 
-	// Make the HTTP GET request
-	resp, err := c.HTTPClient.Get(finalURL.String())
+// helper function to request a resolved URL and decode the response into
+// dest as it is read, failing once it exceeds the client's MaxBodySize
+func streamURL[T any](ctx context.Context, c *Client, rawURL, parameters string, dest *T) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req, resourceKind(parameters))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+
+	limit := c.bodyLimit()
+	if limit < 0 {
+		err = decodeFrom(c, resp.Body, dest)
+	} else {
+		if resp.ContentLength > limit {
+			return fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrBodyTooLarge, resp.ContentLength, limit)
+		}
+		// A body over the limit is cut short, so decoding it fails, and
+		// reading the extra byte tells the two failures apart
+		body := &io.LimitedReader{R: resp.Body, N: limit + 1}
+		err = decodeFrom(c, body, dest)
+		if body.N <= 0 {
+			return fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, limit)
+		}
+	}
+	if err != nil {
+		logDecodeFailure(ctx, c, parameters, dest, err)
+	}
+	return err
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return errors.New("not found")
+// helper function to turn a response that is not a 200 OK into an error
+func checkStatus(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return ErrNotFound
 	}
+	return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+// helper function to fetch the raw response body of a resolved URL, using the cache when possible
+func fetchURL(ctx context.Context, c *Client, key, resource string) ([]byte, error) {
+	// Serve fresh cache entries without a request
+	var cached CacheEntry
+	var hasCached bool
+	if c.Cache != nil {
+		cached, hasCached = c.Cache.Get(key)
+		hit := hasCached && cached.Fresh(time.Now())
+		c.hooks().CacheLookup(ctx, resource, hit)
+		if hit {
+			c.logger().LogAttrs(ctx, slog.LevelDebug, "cache hit", slog.String("url", key))
+			return cached.Body, nil
+		}
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	// Make the HTTP GET request, revalidating any stale cache entry
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, key, nil)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if hasCached {
+		cached.setValidators(req.Header)
+	}
+	resp, err := c.do(req, resource)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	return json.Unmarshal(body, dest)
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		c.logger().LogAttrs(ctx, slog.LevelDebug, "cache revalidated", slog.String("url", key))
+		c.Cache.Set(key, cached.revalidated(resp.Header, c.CacheTTL, time.Now()))
+		return cached.Body, nil
+	}
+
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
+	body, err := readBody(c, resp.Body, resp.ContentLength)
+	if err != nil {
+		return nil, err
+	}
+	body = rewriteReferences(c, body)
+	if c.Cache != nil && cacheable(resp.Header) {
+		c.Cache.Set(key, newCacheEntry(body, resp.Header, c.CacheTTL, time.Now()))
+	}
+	return body, nil
 }
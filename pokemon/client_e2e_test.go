@@ -10,6 +10,8 @@ import (
 )
 
 func TestGetPokemonE2E(t *testing.T) {
+	useCassette(t)
+
 	t.Run("Valid Pokemon", func(t *testing.T) {
 		pokemonName := "pikachu"
 		pokemonLocation := EncountersData("https://pokeapi.co/api/v2/pokemon/25/encounters")
@@ -50,6 +52,8 @@ func TestGetPokemonE2E(t *testing.T) {
 }
 
 func TestGetNatureE2E(t *testing.T) {
+	useCassette(t)
+
 	t.Run("Valid Nature", func(t *testing.T) {
 		natureName := "adamant"
 		nature, err := GetNature(context.Background(), GetNatureOpts{
@@ -71,6 +75,8 @@ func TestGetNatureE2E(t *testing.T) {
 }
 
 func TestGetStatE2E(t *testing.T) {
+	useCassette(t)
+
 	t.Run("Valid Stat", func(t *testing.T) {
 		statName := "speed"
 		stat, err := GetStat(context.Background(), GetStatOpts{
@@ -90,3 +96,15 @@ func TestGetStatE2E(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestSchemaDriftE2E(t *testing.T) {
+	useCassette(t)
+
+	drifts, err := DefaultClient.DetectSchemaDrift(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, drifts)
+	for _, drift := range drifts {
+		// Drift is reported rather than failed on, as the models are known to be incomplete.
+		t.Log(drift)
+	}
+}
@@ -199,7 +199,7 @@ func TestGetStat(t *testing.T) {
 						{Name: "relaxed", URL: "/nature/relaxed"},
 					},
 				},
-				Characteristics: []Characteristic{
+				Characteristics: []APIResource{
 					{URL: "/characteristic/high-speed"},
 				},
 				MoveDamageClass: NamedURL{Name: "physical", URL: "/move-damage-class/physical"},
@@ -0,0 +1,102 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// inflight coalesces concurrent requests for the same URL made by the same
+// client, so they share one round trip and its result.
+var inflight flightGroup
+
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[flightKey]*flight
+}
+
+type flightKey struct {
+	client *Client
+	url    string
+	// dest is the type a streamed response is decoded into, so callers only
+	// share a streamed result with callers decoding the same type. It is nil
+	// for raw bodies.
+	dest reflect.Type
+}
+
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+}
+
+// helper function to call fn unless a call for the same key is already in
+// flight, in which case its result is shared instead
+func (g *flightGroup) do(ctx context.Context, key flightKey, fn func() ([]byte, error)) ([]byte, error) {
+	body, _, err := g.call(ctx, key, fn, nil)
+	return body, err
+}
+
+// helper function like do, for callers that consume the response themselves
+// when they make the request and so have no body to share. Once fn succeeds,
+// share is called for the body only if other callers joined. led reports
+// whether this caller made the request.
+func (g *flightGroup) call(ctx context.Context, key flightKey, fn, share func() ([]byte, error)) (body []byte, led bool, err error) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[flightKey]*flight)
+		}
+		if f, ok := g.calls[key]; ok {
+			f.waiters++
+			g.mu.Unlock()
+			select {
+			case <-f.done:
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			}
+			// The call was cancelled by the context of the goroutine that
+			// made it, not ours, so make the request ourselves.
+			if isContextError(f.err) && ctx.Err() == nil {
+				continue
+			}
+			return f.body, false, f.err
+		}
+
+		f := &flight{done: make(chan struct{})}
+		g.calls[key] = f
+		g.mu.Unlock()
+
+		func() {
+			defer func() {
+				// Followers get an error instead of an empty result when fn
+				// panics, and the panic carries on in this goroutine.
+				r := recover()
+				if r != nil {
+					f.body, f.err = nil, fmt.Errorf("request for %s panicked: %v", key.url, r)
+				}
+				g.mu.Lock()
+				delete(g.calls, key)
+				waiters := f.waiters
+				g.mu.Unlock()
+				if r == nil && f.err == nil && share != nil && waiters > 0 {
+					f.body, f.err = share()
+				}
+				close(f.done)
+				if r != nil {
+					panic(r)
+				}
+			}()
+			body, err = fn()
+			f.body, f.err = body, err
+		}()
+		return body, true, err
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
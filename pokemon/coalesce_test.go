@@ -0,0 +1,171 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// helper function to wait until n callers are waiting on the flight for url
+func waitForWaiters(t *testing.T, c *Client, url string, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		inflight.mu.Lock()
+		defer inflight.mu.Unlock()
+		for key, f := range inflight.calls {
+			if key.client == c && key.url == url {
+				return f.waiters == n
+			}
+		}
+		return false
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestCoalescing(t *testing.T) {
+	tests := []struct {
+		scenario string
+		client   Client
+	}{
+		{scenario: "Streamed responses", client: Client{}},
+		// Rewriting references needs the raw body, so responses are buffered
+		{scenario: "Buffered responses", client: Client{ReferenceBase: PublicEndpoint}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			const callers = 10
+			var pokemonRequests, encounterRequests atomic.Int32
+			client := &tt.client
+			var serverURL string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/pokemon/pikachu":
+					if pokemonRequests.Add(1) == 1 {
+						waitForWaiters(t, client, serverURL+"/pokemon/pikachu", callers-1)
+					}
+					json.NewEncoder(w).Encode(Pokemon{ID: 25, Name: "pikachu"})
+				case "/pokemon/pikachu/encounters":
+					if encounterRequests.Add(1) == 1 {
+						waitForWaiters(t, client, serverURL+"/pokemon/pikachu/encounters", callers-1)
+					}
+					json.NewEncoder(w).Encode([]LocationAreaEncounter{{LocationArea: NamedURL{Name: "viridian-forest-area"}}})
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+			serverURL = server.URL
+			client.HTTPClient = server.Client()
+			client.Endpoint = server.URL
+			require.Equal(t, tt.client.ReferenceBase == "", client.streams())
+
+			var wg sync.WaitGroup
+			results := make([]Pokemon, callers)
+			errs := make([]error, callers)
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					results[i], errs[i] = client.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu", IncludeLocation: true})
+				}(i)
+			}
+			wg.Wait()
+
+			for i := range results {
+				require.NoError(t, errs[i])
+				require.Equal(t, results[0], results[i])
+			}
+			require.Equal(t, "pikachu", results[0].Name)
+			require.Contains(t, string(results[0].LocationAreaEncounters), "viridian-forest-area")
+			require.Equal(t, int32(1), pokemonRequests.Load())
+			require.Equal(t, int32(1), encounterRequests.Load())
+
+			// Calls made after the flight has landed make their own request.
+			_, err := client.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+			require.NoError(t, err)
+			require.Equal(t, int32(2), pokemonRequests.Load())
+		})
+	}
+}
+
+func TestCoalescingCancellation(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		json.NewEncoder(w).Encode(Nature{ID: 1, Name: "hardy"})
+	}))
+	defer server.Close()
+	defer close(release)
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := client.GetNature(ctx, GetNatureOpts{Name: "hardy"})
+		leader <- err
+	}()
+	require.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, time.Millisecond)
+
+	follower := make(chan error)
+	go func() {
+		nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		if err == nil && nature.Name != "hardy" {
+			err = context.Canceled
+		}
+		follower <- err
+	}()
+	waitForWaiters(t, client, server.URL+"/nature/hardy", 1)
+
+	// Cancelling the request the follower joined makes it fetch on its own.
+	cancel()
+	require.ErrorIs(t, <-leader, context.Canceled)
+	require.NoError(t, <-follower)
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestCoalescingPanic(t *testing.T) {
+	var group flightGroup
+	key := flightKey{url: "https://pokeapi.co/api/v2/nature/hardy"}
+	waiting := func() bool {
+		group.mu.Lock()
+		defer group.mu.Unlock()
+		f, ok := group.calls[key]
+		return ok && f.waiters == 1
+	}
+
+	leader := make(chan any)
+	go func() {
+		defer func() { leader <- recover() }()
+		group.do(context.Background(), key, func() ([]byte, error) {
+			require.Eventually(t, waiting, 5*time.Second, time.Millisecond)
+			panic("boom")
+		})
+	}()
+	require.Eventually(t, func() bool {
+		group.mu.Lock()
+		defer group.mu.Unlock()
+		return group.calls[key] != nil
+	}, time.Second, time.Millisecond)
+
+	body, err := group.do(context.Background(), key, func() ([]byte, error) {
+		t.Error("the follower should share the leader's call")
+		return nil, nil
+	})
+	require.Nil(t, body)
+	require.ErrorContains(t, err, "panicked: boom")
+	require.Equal(t, "boom", <-leader, "the panic is re-raised in the leader")
+}
@@ -0,0 +1,165 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+type ContestName struct {
+	Name     string   `json:"name"`
+	Color    string   `json:"color"`
+	Language NamedURL `json:"language"`
+}
+
+// ContestNames is the list of names and colors a contest type has across languages.
+type ContestNames []ContestName
+
+// ContestType represents a contest condition (cool, beauty, cute, smart or tough).
+type ContestType struct {
+	ID          int                        `json:"id"`
+	Name        string                     `json:"name"`
+	BerryFlavor NamedURL                   `json:"berry_flavor"`
+	Names       ContestNames               `json:"names"`
+	Extra       map[string]json.RawMessage `json:"-"`
+}
+
+// ContestEffect represents the appeal and jam of a move used in a contest.
+type ContestEffect struct {
+	ID                int                        `json:"id"`
+	Appeal            int                        `json:"appeal"`
+	Jam               int                        `json:"jam"`
+	EffectEntries     EffectEntries              `json:"effect_entries"`
+	FlavorTextEntries FlavorTextEntries          `json:"flavor_text_entries"`
+	Extra             map[string]json.RawMessage `json:"-"`
+}
+
+// SuperContestEffect represents the appeal of a move used in a super contest.
+type SuperContestEffect struct {
+	ID                int                        `json:"id"`
+	Appeal            int                        `json:"appeal"`
+	FlavorTextEntries FlavorTextEntries          `json:"flavor_text_entries"`
+	Moves             []NamedURL                 `json:"moves"`
+	Extra             map[string]json.RawMessage `json:"-"`
+}
+
+type FlavorBerryMap struct {
+	Potency int      `json:"potency"`
+	Berry   NamedURL `json:"berry"`
+}
+
+// BerryFlavor represents a berry flavor and the contest type it raises.
+type BerryFlavor struct {
+	ID          int                        `json:"id"`
+	Name        string                     `json:"name"`
+	Berries     []FlavorBerryMap           `json:"berries"`
+	ContestType NamedURL                   `json:"contest_type"`
+	Names       LocalizedNames             `json:"names"`
+	Extra       map[string]json.RawMessage `json:"-"`
+}
+
+// NatureContestConditions holds the contest conditions a nature's liked and
+// hated flavors map to. Both are empty for neutral natures.
+type NatureContestConditions struct {
+	Likes ContestType
+	Hates ContestType
+}
+
+// GetContestTypeOpts contains options for GetContestType function.
+type GetContestTypeOpts struct {
+	// ID is the ID of the ContestType to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the ContestType to retrieve.
+	Name string
+}
+
+// GetContestEffectOpts contains options for GetContestEffect function.
+type GetContestEffectOpts struct {
+	// ID is the ID of the ContestEffect to retrieve. Contest effects have no name.
+	ID int
+}
+
+// GetSuperContestEffectOpts contains options for GetSuperContestEffect function.
+type GetSuperContestEffectOpts struct {
+	// ID is the ID of the SuperContestEffect to retrieve. Super contest effects have no name.
+	ID int
+}
+
+// GetBerryFlavorOpts contains options for GetBerryFlavor function.
+type GetBerryFlavorOpts struct {
+	// ID is the ID of the BerryFlavor to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the BerryFlavor to retrieve.
+	Name string
+}
+
+// GetContestType gets a contest type by ID or Name.
+func (c *Client) GetContestType(ctx context.Context, opts GetContestTypeOpts) (ContestType, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return ContestType{}, err
+	}
+	return Get[ContestType](ctx, c, lookupValue)
+}
+
+// GetContestEffect gets a contest effect by ID.
+func (c *Client) GetContestEffect(ctx context.Context, opts GetContestEffectOpts) (ContestEffect, error) {
+	lookupValue, err := getLookupValue(opts.ID, "")
+	if err != nil {
+		return ContestEffect{}, err
+	}
+	return Get[ContestEffect](ctx, c, lookupValue)
+}
+
+// GetSuperContestEffect gets a super contest effect by ID.
+func (c *Client) GetSuperContestEffect(ctx context.Context, opts GetSuperContestEffectOpts) (SuperContestEffect, error) {
+	lookupValue, err := getLookupValue(opts.ID, "")
+	if err != nil {
+		return SuperContestEffect{}, err
+	}
+	return Get[SuperContestEffect](ctx, c, lookupValue)
+}
+
+// GetBerryFlavor gets a berry flavor by ID or Name.
+func (c *Client) GetBerryFlavor(ctx context.Context, opts GetBerryFlavorOpts) (BerryFlavor, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return BerryFlavor{}, err
+	}
+	return Get[BerryFlavor](ctx, c, lookupValue)
+}
+
+// NameIn returns the name in lang, falling back like LocalizedNames.NameIn.
+func (n ContestNames) NameIn(lang string, fallback ...string) string {
+	return pickLocalized(n, func(entry ContestName) (string, string) {
+		return entry.Language.Name, entry.Name
+	}, lang, fallback)
+}
+
+// GetNatureContestConditions resolves the flavors a nature likes and hates to
+// the contest conditions they raise, following BerryFlavor.ContestType.
+func (c *Client) GetNatureContestConditions(ctx context.Context, nature Nature) (NatureContestConditions, error) {
+	var conditions NatureContestConditions
+	var err error
+	conditions.Likes, err = c.contestTypeForFlavor(ctx, nature.LikesFlavor)
+	if err != nil {
+		return conditions, err
+	}
+	conditions.Hates, err = c.contestTypeForFlavor(ctx, nature.HatesFlavor)
+	return conditions, err
+}
+
+// helper function to follow a flavor reference through to its contest type
+func (c *Client) contestTypeForFlavor(ctx context.Context, flavorRef NamedURL) (ContestType, error) {
+	if flavorRef.Name == "" {
+		return ContestType{}, nil
+	}
+	flavor, err := c.GetBerryFlavor(ctx, GetBerryFlavorOpts{Name: flavorRef.Name})
+	if err != nil {
+		return ContestType{}, err
+	}
+	if flavor.ContestType.Name == "" {
+		return ContestType{}, errors.New("berry flavor has no contest type")
+	}
+	return c.GetContestType(ctx, GetContestTypeOpts{Name: flavor.ContestType.Name})
+}
@@ -0,0 +1,156 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetContestType(t *testing.T) {
+	tests := []struct {
+		scenario    string
+		contestName string
+		expected    ContestType
+		err         bool
+	}{
+		{
+			scenario:    "Successful retrieval of a contest type",
+			contestName: "cool",
+			expected: ContestType{
+				ID:          1,
+				Name:        "cool",
+				BerryFlavor: NamedURL{Name: "spicy", URL: "/berry-flavor/1"},
+				Names: []ContestName{
+					{Name: "Cool", Color: "Red", Language: NamedURL{Name: "en", URL: "/language/9"}},
+				},
+			},
+			err: false,
+		},
+		{
+			scenario:    "Contest type not found",
+			contestName: "unknown",
+			expected:    ContestType{},
+			err:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.err {
+					http.Error(w, "Contest type not found", http.StatusNotFound)
+					return
+				}
+				require.Equal(t, "/contest-type/"+tt.contestName, r.URL.Path)
+				json.NewEncoder(w).Encode(tt.expected)
+			}))
+			defer server.Close()
+
+			client := &Client{
+				HTTPClient: server.Client(),
+				Endpoint:   server.URL,
+			}
+
+			contestType, err := client.GetContestType(context.Background(), GetContestTypeOpts{
+				Name: tt.contestName,
+			})
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, contestType)
+		})
+	}
+}
+
+func TestGetContestEffect(t *testing.T) {
+	expected := ContestEffect{
+		ID:     1,
+		Appeal: 4,
+		Jam:    0,
+		EffectEntries: []EffectEntry{
+			{Effect: "Gives a high number of appeal points with no other effects.", Language: NamedURL{Name: "en", URL: "/language/9"}},
+		},
+		FlavorTextEntries: []FlavorTextEntry{
+			{FlavorText: "A highly appealing move.", Language: NamedURL{Name: "en", URL: "/language/9"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/contest-effect/1", r.URL.Path)
+		json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	effect, err := client.GetContestEffect(context.Background(), GetContestEffectOpts{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, expected, effect)
+
+	_, err = client.GetContestEffect(context.Background(), GetContestEffectOpts{})
+	require.Error(t, err)
+}
+
+func TestGetSuperContestEffect(t *testing.T) {
+	expected := SuperContestEffect{
+		ID:     1,
+		Appeal: 2,
+		FlavorTextEntries: []FlavorTextEntry{
+			{FlavorText: "Enables the user to perform first in the next turn.", Language: NamedURL{Name: "en", URL: "/language/9"}},
+		},
+		Moves: []NamedURL{{Name: "agility", URL: "/move/97"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/super-contest-effect/1", r.URL.Path)
+		json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	effect, err := client.GetSuperContestEffect(context.Background(), GetSuperContestEffectOpts{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, expected, effect)
+}
+
+func TestGetNatureContestConditions(t *testing.T) {
+	responses := map[string]any{
+		"/berry-flavor/spicy": BerryFlavor{ID: 1, Name: "spicy", ContestType: NamedURL{Name: "cool", URL: "/contest-type/1"}},
+		"/berry-flavor/sweet": BerryFlavor{ID: 3, Name: "sweet", ContestType: NamedURL{Name: "cute", URL: "/contest-type/3"}},
+		"/contest-type/cool":  ContestType{ID: 1, Name: "cool", BerryFlavor: NamedURL{Name: "spicy", URL: "/berry-flavor/1"}},
+		"/contest-type/cute":  ContestType{ID: 3, Name: "cute", BerryFlavor: NamedURL{Name: "sweet", URL: "/berry-flavor/3"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	t.Run("Nature with flavor preferences", func(t *testing.T) {
+		conditions, err := client.GetNatureContestConditions(context.Background(), Nature{
+			Name:        "brave",
+			LikesFlavor: NamedURL{Name: "spicy", URL: "/berry-flavor/1"},
+			HatesFlavor: NamedURL{Name: "sweet", URL: "/berry-flavor/3"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "cool", conditions.Likes.Name)
+		require.Equal(t, "cute", conditions.Hates.Name)
+	})
+
+	t.Run("Neutral nature", func(t *testing.T) {
+		conditions, err := client.GetNatureContestConditions(context.Background(), Nature{Name: "hardy"})
+		require.NoError(t, err)
+		require.Equal(t, NatureContestConditions{}, conditions)
+	})
+}
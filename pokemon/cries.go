@@ -0,0 +1,58 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNoCry is returned when a Pokémon has no cry for a variant.
+var ErrNoCry = errors.New("no cry")
+
+// PokemonCries holds the URLs of a Pokémon's cries, as OGG audio files.
+type PokemonCries struct {
+	// Latest is the cry used by the most recent games.
+	Latest string `json:"latest"`
+	// Legacy is the cry from the Pokémon's original generation, or empty if
+	// it has none.
+	Legacy string `json:"legacy"`
+}
+
+// CryVariant selects one of a Pokémon's cries.
+type CryVariant string
+
+const (
+	CryLatest CryVariant = "latest"
+	CryLegacy CryVariant = "legacy"
+)
+
+// URL returns the URL of a cry variant, or "" if the Pokémon has none.
+func (c PokemonCries) URL(variant CryVariant) string {
+	switch variant {
+	case CryLatest:
+		return c.Latest
+	case CryLegacy:
+		return c.Legacy
+	}
+	return ""
+}
+
+// DownloadCry streams a cry of a Pokémon to w. With a Cache the audio is
+// cached by URL, and a cached cry is played even when the request fails, so
+// use a DirCache to keep cries available offline. It returns ErrNoCry if the
+// Pokémon has no cry for the variant.
+func (c *Client) DownloadCry(ctx context.Context, pokemon Pokemon, variant CryVariant, w io.Writer) (err error) {
+	ctx, end := c.hooks().StartCall(ctx, "pokemon.DownloadCry")
+	defer func() { end(err) }()
+
+	cryURL := pokemon.Cries.URL(variant)
+	if cryURL == "" {
+		return fmt.Errorf("%w: %s has no %s cry", ErrNoCry, pokemon.Name, variant)
+	}
+	_, err = c.download(ctx, cryURL, "cry", w)
+	if err != nil {
+		return fmt.Errorf("error downloading %s cry of %s: %w", variant, pokemon.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package pokemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newCryServer serves pikachu and its latest cry. Pikachu has no legacy cry.
+func newCryServer(t *testing.T, cryRequests *atomic.Int32) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon/pikachu":
+			fmt.Fprintf(w, `{"id": 25, "name": "pikachu", "cries": {"latest": "%s/cries/latest/25.ogg", "legacy": null}}`, server.URL)
+		case "/cries/latest/25.ogg":
+			cryRequests.Add(1)
+			w.Header().Set("Content-Type", "audio/ogg")
+			fmt.Fprint(w, "OggS:pikachu")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownloadCry(t *testing.T) {
+	var cryRequests atomic.Int32
+	server := newCryServer(t, &cryRequests)
+	c := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/"}
+
+	pikachu, err := c.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/cries/latest/25.ogg", pikachu.Cries.Latest)
+	require.Empty(t, pikachu.Cries.Legacy)
+
+	var buf bytes.Buffer
+	require.NoError(t, c.DownloadCry(context.Background(), pikachu, CryLatest, &buf))
+	require.Equal(t, "OggS:pikachu", buf.String())
+
+	err = c.DownloadCry(context.Background(), pikachu, CryLegacy, &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrNoCry)
+}
+
+func TestDownloadCryHeaders(t *testing.T) {
+	var mediaAuthorization atomic.Value
+	media := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaAuthorization.Store(r.Header.Get("Authorization"))
+		fmt.Fprint(w, "OggS:pikachu")
+	}))
+	t.Cleanup(media.Close)
+	var apiAuthorization atomic.Value
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiAuthorization.Store(r.Header.Get("Authorization"))
+		fmt.Fprintf(w, `{"id": 25, "name": "pikachu", "cries": {"latest": "%s/cries/latest/25.ogg"}}`, media.URL)
+	}))
+	t.Cleanup(api.Close)
+	c := &Client{
+		HTTPClient: http.DefaultClient,
+		Endpoint:   api.URL + "/",
+		Middleware: []Middleware{Headers(http.Header{"Authorization": {"Bearer secret"}})},
+	}
+
+	pikachu, err := c.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+	require.NoError(t, err)
+	require.Equal(t, "Bearer secret", apiAuthorization.Load())
+
+	require.NoError(t, c.DownloadCry(context.Background(), pikachu, CryLatest, &bytes.Buffer{}))
+	require.Empty(t, mediaAuthorization.Load())
+}
+
+func TestDownloadCryCache(t *testing.T) {
+	t.Run("Serves fresh entries without a request", func(t *testing.T) {
+		var cryRequests atomic.Int32
+		server := newCryServer(t, &cryRequests)
+		c := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/", Cache: NewMemoryCache(), CacheTTL: time.Hour}
+		pikachu, err := c.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			var buf bytes.Buffer
+			require.NoError(t, c.DownloadCry(context.Background(), pikachu, CryLatest, &buf))
+			require.Equal(t, "OggS:pikachu", buf.String())
+		}
+		require.EqualValues(t, 1, cryRequests.Load())
+	})
+
+	t.Run("Plays cached cries offline", func(t *testing.T) {
+		var cryRequests atomic.Int32
+		server := newCryServer(t, &cryRequests)
+		dir := t.TempDir()
+		online := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/", Cache: NewDirCache(dir)}
+		pikachu, err := online.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+		require.NoError(t, err)
+		require.NoError(t, online.DownloadCry(context.Background(), pikachu, CryLatest, &bytes.Buffer{}))
+		server.Close()
+
+		// A new client, as after a restart, with the same cache directory
+		offline := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/", Cache: NewDirCache(dir)}
+		var buf bytes.Buffer
+		require.NoError(t, offline.DownloadCry(context.Background(), pikachu, CryLatest, &buf))
+		require.Equal(t, "OggS:pikachu", buf.String())
+		require.EqualValues(t, 1, cryRequests.Load())
+	})
+
+	t.Run("Fails offline without a cached cry", func(t *testing.T) {
+		var cryRequests atomic.Int32
+		server := newCryServer(t, &cryRequests)
+		c := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/", Cache: NewDirCache(t.TempDir())}
+		pikachu, err := c.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+		require.NoError(t, err)
+		server.Close()
+
+		require.Error(t, c.DownloadCry(context.Background(), pikachu, CryLatest, &bytes.Buffer{}))
+	})
+}
@@ -0,0 +1,126 @@
+package pokemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// DefaultMaxBodySize is the largest response body read when
+// Client.MaxBodySize is zero.
+const DefaultMaxBodySize = 8 << 20
+
+// ErrBodyTooLarge is returned when a response body exceeds the client's MaxBodySize.
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// DecodeMode controls how fields the models don't know about are handled.
+type DecodeMode int
+
+const (
+	// DecodeIgnoreUnknown drops fields the models don't know about.
+	DecodeIgnoreUnknown DecodeMode = iota
+	// DecodeStrict fails with an error naming the first unknown field, e.g.
+	// to detect changes to the API's schema.
+	DecodeStrict
+	// DecodeLenient keeps the unknown top-level fields of a resource in its
+	// Extra field as raw JSON.
+	DecodeLenient
+)
+
+// extraField is the name of the field DecodeLenient stores unknown fields in.
+const extraField = "Extra"
+
+// helper function to read a response body, failing once it exceeds the client's MaxBodySize
+func readBody(c *Client, body io.Reader, contentLength int64) ([]byte, error) {
+	limit := c.bodyLimit()
+	if limit < 0 {
+		return io.ReadAll(body)
+	}
+	if contentLength > limit {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrBodyTooLarge, contentLength, limit)
+	}
+
+	var buf bytes.Buffer
+	if contentLength > 0 {
+		buf.Grow(int(contentLength))
+	}
+	n, err := buf.ReadFrom(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, limit)
+	}
+	return buf.Bytes(), nil
+}
+
+// helper function to get the client's MaxBodySize, negative for no limit
+func (c *Client) bodyLimit() int64 {
+	if c.MaxBodySize == 0 {
+		return DefaultMaxBodySize
+	}
+	return c.MaxBodySize
+}
+
+// helper function to decode body into dest following the client's DecodeMode
+func decode(c *Client, body []byte, dest any) error {
+	if err := decodeFrom(c, bytes.NewReader(body), dest); err != nil {
+		return err
+	}
+	if c.DecodeMode == DecodeLenient {
+		return keepExtra(body, dest)
+	}
+	return nil
+}
+
+// helper function to decode the JSON value read from r into dest, rejecting
+// unknown fields with DecodeStrict. DecodeLenient needs the whole body, so
+// it is handled by decode.
+func decodeFrom(c *Client, r io.Reader, dest any) error {
+	decoder := json.NewDecoder(r)
+	if c.DecodeMode == DecodeStrict {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(dest)
+}
+
+// helper function to store the top-level fields of body that dest has no
+// field for in dest's Extra field, if it has one
+func keepExtra(body []byte, dest any) error {
+	v := reflect.ValueOf(dest).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	extra := v.FieldByName(extraField)
+	if !extra.IsValid() || extra.Type() != reflect.TypeOf(map[string]json.RawMessage(nil)) {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return err
+	}
+	// encoding/json matches field names case-insensitively, so do the same
+	known := make(map[string]bool)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+	for name := range fields {
+		if known[strings.ToLower(name)] {
+			delete(fields, name)
+		}
+	}
+	if len(fields) > 0 {
+		extra.Set(reflect.ValueOf(fields))
+	}
+	return nil
+}
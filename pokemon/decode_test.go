@@ -0,0 +1,132 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	body := `{"id": 1, "name": "hardy", "names": [` + strings.Repeat(`{"name": "Hardy"},`, 100) + `{"name": "Hardy"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nature/chunked" {
+			// Flushing before writing the body leaves out Content-Length.
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		scenario string
+		name     string
+		limit    int64
+		err      bool
+	}{
+		{scenario: "Default limit", name: "hardy", limit: 0},
+		{scenario: "Body within the limit", name: "hardy", limit: int64(len(body))},
+		{scenario: "Content-Length over the limit", name: "hardy", limit: 100, err: true},
+		{scenario: "Chunked body over the limit", name: "chunked", limit: 100, err: true},
+		{scenario: "No limit", name: "chunked", limit: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			streamed := &Client{HTTPClient: server.Client(), Endpoint: server.URL, MaxBodySize: tt.limit}
+			cached := &Client{HTTPClient: server.Client(), Endpoint: server.URL, MaxBodySize: tt.limit, Cache: NewMemoryCache()}
+			for _, client := range []*Client{streamed, cached} {
+				nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: tt.name})
+				if tt.err {
+					require.ErrorIs(t, err, ErrBodyTooLarge)
+					continue
+				}
+				require.NoError(t, err)
+				require.Len(t, nature.Names, 101)
+			}
+		})
+	}
+}
+
+func TestStreamedDecoding(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "hardy"}`))
+		w.(http.Flusher).Flush()
+		// Hold the body open, so only a client decoding as it reads returns
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+	done := make(chan error)
+	go func() {
+		nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		if err == nil {
+			require.Equal(t, "hardy", nature.Name)
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the response was not decoded before the body ended")
+	}
+}
+
+func TestDecodeModes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "hardy", "Likes_Flavor": {"name": "spicy"}, "hp_bonus": 10, "tags": ["neutral"]}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		scenario string
+		mode     DecodeMode
+		extra    map[string]json.RawMessage
+		err      string
+	}{
+		{scenario: "Unknown fields are ignored by default", mode: DecodeIgnoreUnknown},
+		{scenario: "Strict mode rejects unknown fields", mode: DecodeStrict, err: `unknown field "hp_bonus"`},
+		{
+			scenario: "Lenient mode keeps unknown fields",
+			mode:     DecodeLenient,
+			extra: map[string]json.RawMessage{
+				"hp_bonus": json.RawMessage(`10`),
+				"tags":     json.RawMessage(`["neutral"]`),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			client := &Client{HTTPClient: server.Client(), Endpoint: server.URL, DecodeMode: tt.mode}
+			nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "hardy", nature.Name)
+			require.Equal(t, "spicy", nature.LikesFlavor.Name)
+			require.Equal(t, tt.extra, nature.Extra)
+		})
+	}
+
+	t.Run("Lenient mode leaves types without Extra alone", func(t *testing.T) {
+		client := &Client{HTTPClient: server.Client(), Endpoint: server.URL, DecodeMode: DecodeLenient}
+		var ref NamedURL
+		require.NoError(t, fetchAndUnmarshal(context.Background(), client, "nature/hardy", &ref))
+		require.Equal(t, "hardy", ref.Name)
+	})
+}
@@ -0,0 +1,221 @@
+package pokemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaDrift lists the differences between an API payload and the model it
+// decodes into. Paths use the JSON names, with [] for array elements and {}
+// for map values, e.g. "stats[].stat".
+type SchemaDrift struct {
+	// Path is the request path of the payload, such as "pokemon/25".
+	Path string
+	// Type is the name of the model, such as "Pokemon".
+	Type string
+	// Unmapped lists fields present in the payload that no model field maps.
+	Unmapped []string
+	// Unpopulated lists model fields the payload never filled.
+	Unpopulated []string
+}
+
+// Empty reports whether the payload and model match.
+func (d SchemaDrift) Empty() bool {
+	return len(d.Unmapped) == 0 && len(d.Unpopulated) == 0
+}
+
+func (d SchemaDrift) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s):", d.Type, d.Path)
+	if d.Empty() {
+		b.WriteString(" no drift")
+	}
+	if len(d.Unmapped) > 0 {
+		fmt.Fprintf(&b, " unmapped %s;", strings.Join(d.Unmapped, ", "))
+	}
+	if len(d.Unpopulated) > 0 {
+		fmt.Fprintf(&b, " unpopulated %s;", strings.Join(d.Unpopulated, ", "))
+	}
+	return strings.TrimSuffix(b.String(), ";")
+}
+
+// CompareSchema compares a JSON payload with the model T.
+func CompareSchema[T Resource](payload []byte) (SchemaDrift, error) {
+	return compareSchema(typeOf[T](), payload)
+}
+
+// DetectSchemaDrift fetches the first resource listed for every registered
+// resource type and compares it with its model. Resources with an empty or
+// missing list endpoint are skipped. Point the client at a snapshot or
+// cassette to check recorded payloads instead of the live API.
+func (c *Client) DetectSchemaDrift(ctx context.Context) ([]SchemaDrift, error) {
+	registry.RLock()
+	types := make(map[string]reflect.Type, len(registry.paths))
+	for t, path := range registry.paths {
+		types[path] = t
+	}
+	registry.RUnlock()
+
+	var drifts []SchemaDrift
+	for _, path := range RegisteredPaths() {
+		list, err := c.ListResources(ctx, path, ListOpts{Limit: 1})
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return drifts, fmt.Errorf("error listing %s: %w", path, err)
+		}
+		if len(list.Results) == 0 {
+			continue
+		}
+		key := path + "/" + list.Results[0].Name
+		if id := list.Results[0].ID(); id != 0 {
+			key = path + "/" + strconv.Itoa(id)
+		}
+		payload, err := fetchBody(ctx, c, key)
+		if err != nil {
+			return drifts, fmt.Errorf("error fetching %s: %w", key, err)
+		}
+		drift, err := compareSchema(types[path], payload)
+		if err != nil {
+			return drifts, fmt.Errorf("error comparing %s: %w", key, err)
+		}
+		drift.Path = key
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// helper function to compare a payload with the model t
+func compareSchema(t reflect.Type, payload []byte) (SchemaDrift, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return SchemaDrift{}, err
+	}
+
+	w := driftWalker{unmapped: make(map[string]bool), candidates: make(map[string]bool), seen: make(map[string]bool)}
+	w.walk(value, t, "")
+
+	drift := SchemaDrift{Type: t.Name()}
+	for path := range w.unmapped {
+		drift.Unmapped = append(drift.Unmapped, path)
+	}
+	for path := range w.candidates {
+		if !w.seen[path] {
+			drift.Unpopulated = append(drift.Unpopulated, path)
+		}
+	}
+	sort.Strings(drift.Unmapped)
+	sort.Strings(drift.Unpopulated)
+	return drift, nil
+}
+
+// driftWalker collects the paths of a payload and model as it walks them.
+// A model field is a candidate once its parent object appears in the
+// payload, and seen once the field does; across array elements a field
+// counts as populated if any element has it.
+type driftWalker struct {
+	unmapped   map[string]bool
+	candidates map[string]bool
+	seen       map[string]bool
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+func (w *driftWalker) walk(value any, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == rawMessageType {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for name := range fields {
+				w.candidates[joinPath(path, name)] = true
+			}
+			for key, child := range v {
+				name, ok := matchField(fields, key)
+				if !ok {
+					w.unmapped[joinPath(path, key)] = true
+					continue
+				}
+				w.seen[joinPath(path, name)] = true
+				w.walk(child, fields[name], joinPath(path, name))
+			}
+		case reflect.Map:
+			for _, child := range v {
+				w.walk(child, t.Elem(), path+"{}")
+			}
+		}
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, child := range v {
+				w.walk(child, t.Elem(), path+"[]")
+			}
+		}
+	}
+}
+
+// helper function to map the JSON names of a struct's fields to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// encoding/json promotes the fields of untagged embedded structs
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, t := range jsonFields(field.Type) {
+				if _, ok := fields[name]; !ok {
+					fields[name] = t
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// helper function to find the field a JSON key decodes into, matching
+// case-insensitively like encoding/json
+func matchField(fields map[string]reflect.Type, key string) (string, bool) {
+	if _, ok := fields[key]; ok {
+		return key, true
+	}
+	for name := range fields {
+		if strings.EqualFold(name, key) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
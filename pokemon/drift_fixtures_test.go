@@ -0,0 +1,39 @@
+package pokemon_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ashgodfrey/pokemon-api/pokemon"
+	"github.com/ashgodfrey/pokemon-api/pokemon/pokemontest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSchemaDrift(t *testing.T) {
+	server := pokemontest.NewServer()
+	defer server.Close()
+
+	drifts, err := server.Client().DetectSchemaDrift(context.Background())
+	require.NoError(t, err)
+
+	byType := make(map[string]pokemon.SchemaDrift)
+	for _, drift := range drifts {
+		byType[drift.Type] = drift
+	}
+	require.ElementsMatch(t, []string{"Language", "Nature", "Pokemon", "PokemonColor", "Stat"}, keys(byType))
+
+	bulbasaur := byType["Pokemon"]
+	require.Equal(t, "pokemon/1", bulbasaur.Path)
+	require.Contains(t, bulbasaur.Unmapped, "types")
+	require.Contains(t, bulbasaur.Unpopulated, "type")
+	require.NotContains(t, bulbasaur.Unmapped, "stats[].stat")
+	require.NotContains(t, bulbasaur.Unpopulated, "stats[].stat")
+}
+
+func keys[V any](m map[string]V) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
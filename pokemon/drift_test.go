@@ -0,0 +1,71 @@
+package pokemon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareSchema(t *testing.T) {
+	tests := []struct {
+		scenario string
+		payload  string
+		expected SchemaDrift
+	}{
+		{
+			scenario: "Unknown field in an array",
+			payload: `{"id": 25, "name": "pikachu", "stats": [
+				{"base_stat": 35, "effort": 0, "stat": {"name": "hp", "url": "/stat/1/"}},
+				{"base_stat": 90, "effort": 2, "stat": {"name": "speed", "url": "/stat/6/"}, "rank": 1}
+			]}`,
+			expected: SchemaDrift{
+				Type:     "Pokemon",
+				Unmapped: []string{"stats[].rank"},
+				Unpopulated: []string{
+					"ability", "base_experience", "cries", "form", "generation", "height", "is_default", "is_hidden",
+					"item", "location_area_encounters", "move", "order", "slot", "species", "sprites",
+					"type", "version", "weight",
+				},
+			},
+		},
+		{
+			scenario: "Fields populated by any array element",
+			payload: `{"id": 1, "name": "hardy", "decreased_stat": null, "increased_stat": null, "likes_flavor": null,
+				"hates_flavor": null, "pokeathlon_stat_changes": [], "move_battle_style_preferences": [
+					{"low_hp_preference": 61, "move_battle_style": {"name": "attack", "url": "/move-battle-style/1/"}},
+					{"high_hp_preference": 61, "move_battle_style": {"name": "defense"}}
+				], "names": [{"name": "Hardy", "language": {"name": "en", "url": "/language/9/"}, "game": "x"}]}`,
+			expected: SchemaDrift{
+				Type:     "Nature",
+				Unmapped: []string{"names[].game"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			var drift SchemaDrift
+			var err error
+			switch tt.expected.Type {
+			case "Pokemon":
+				drift, err = CompareSchema[Pokemon]([]byte(tt.payload))
+			case "Nature":
+				drift, err = CompareSchema[Nature]([]byte(tt.payload))
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, drift)
+		})
+	}
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := CompareSchema[Nature]([]byte(`{"id":`))
+		require.Error(t, err)
+	})
+}
+
+func TestSchemaDriftString(t *testing.T) {
+	require.Equal(t, "Stat (stat/1): no drift", SchemaDrift{Path: "stat/1", Type: "Stat"}.String())
+	require.Equal(t,
+		"Pokemon (pokemon/25): unmapped types; unpopulated type",
+		SchemaDrift{Path: "pokemon/25", Type: "Pokemon", Unmapped: []string{"types"}, Unpopulated: []string{"type"}}.String())
+}
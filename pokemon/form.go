@@ -0,0 +1,54 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type PokemonFormType struct {
+	Slot int      `json:"slot"`
+	Type NamedURL `json:"type"`
+}
+
+type PokemonFormSprites struct {
+	FrontDefault string `json:"front_default"`
+	FrontShiny   string `json:"front_shiny"`
+	BackDefault  string `json:"back_default"`
+	BackShiny    string `json:"back_shiny"`
+}
+
+// PokemonForm represents one of the forms a Pokémon can take, such as an Unown letter.
+type PokemonForm struct {
+	ID           int                        `json:"id"`
+	Name         string                     `json:"name"`
+	Order        int                        `json:"order"`
+	FormOrder    int                        `json:"form_order"`
+	IsDefault    bool                       `json:"is_default"`
+	IsBattleOnly bool                       `json:"is_battle_only"`
+	IsMega       bool                       `json:"is_mega"`
+	FormName     string                     `json:"form_name"`
+	Pokemon      NamedURL                   `json:"pokemon"`
+	Types        []PokemonFormType          `json:"types"`
+	Sprites      PokemonFormSprites         `json:"sprites"`
+	VersionGroup NamedURL                   `json:"version_group"`
+	Names        LocalizedNames             `json:"names"`
+	FormNames    LocalizedNames             `json:"form_names"`
+	Extra        map[string]json.RawMessage `json:"-"`
+}
+
+// GetPokemonFormOpts contains options for GetPokemonForm function.
+type GetPokemonFormOpts struct {
+	// ID is the ID of the PokemonForm to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the PokemonForm to retrieve.
+	Name string
+}
+
+// GetPokemonForm gets a Pokémon form by ID or Name.
+func (c *Client) GetPokemonForm(ctx context.Context, opts GetPokemonFormOpts) (PokemonForm, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return PokemonForm{}, err
+	}
+	return Get[PokemonForm](ctx, c, lookupValue)
+}
@@ -0,0 +1,49 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPokemonForm(t *testing.T) {
+	expected := PokemonForm{
+		ID:        10041,
+		Name:      "arceus-bug",
+		Order:     631,
+		FormOrder: 7,
+		FormName:  "bug",
+		Pokemon:   NamedURL{Name: "arceus", URL: "/pokemon/493"},
+		Types: []PokemonFormType{
+			{Slot: 1, Type: NamedURL{Name: "bug", URL: "/type/7"}},
+		},
+		Sprites: PokemonFormSprites{
+			FrontDefault: "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/493-bug.png",
+		},
+		VersionGroup: NamedURL{Name: "diamond-pearl", URL: "/version-group/8"},
+		FormNames: []NatureName{
+			{Name: "Bug Type", Language: NamedURL{Name: "en", URL: "/language/9"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon-form/arceus-bug" {
+			http.Error(w, "Pokemon form not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	form, err := client.GetPokemonForm(context.Background(), GetPokemonFormOpts{Name: "arceus-bug"})
+	require.NoError(t, err)
+	require.Equal(t, expected, form)
+
+	_, err = client.GetPokemonForm(context.Background(), GetPokemonFormOpts{Name: "unknown"})
+	require.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package pokemon
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Hooks receives instrumentation events from a Client, e.g. to record traces
+// and metrics. Embed NopHooks to implement only some of the methods.
+type Hooks interface {
+	// StartCall is called when an SDK call such as "pokemon.GetPokemon"
+	// starts. The returned context is used for every request the call makes
+	// and end is called with the call's error once it returns.
+	StartCall(ctx context.Context, name string) (_ context.Context, end func(err error))
+	// StartRequest is called before each HTTP request. The returned
+	// context is used for the request and end is called once the response
+	// headers arrive or the request fails.
+	StartRequest(ctx context.Context, info RequestInfo) (_ context.Context, end func(RequestResult))
+	// CacheLookup is called whenever the client checks its Cache. hit is true
+	// when a fresh entry is served without a request.
+	CacheLookup(ctx context.Context, resource string, hit bool)
+}
+
+// RequestInfo describes an HTTP request.
+type RequestInfo struct {
+	Method string
+	URL    string
+	// Resource is the kind of resource requested, without IDs or names, such
+	// as "pokemon" or "pokemon/encounters".
+	Resource string
+}
+
+// RequestResult describes how an HTTP request ended.
+type RequestResult struct {
+	// StatusCode is zero when the request failed without a response.
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+// NopHooks implements Hooks by doing nothing.
+type NopHooks struct{}
+
+func (NopHooks) StartCall(ctx context.Context, name string) (context.Context, func(error)) {
+	return ctx, func(error) {}
+}
+
+func (NopHooks) StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult)) {
+	return ctx, func(RequestResult) {}
+}
+
+func (NopHooks) CacheLookup(ctx context.Context, resource string, hit bool) {}
+
+// MultiHooks combines hooks so a client can report to several of them, e.g.
+// both tracing and metrics. Events are delivered in order and ended in reverse.
+func MultiHooks(hooks ...Hooks) Hooks {
+	return multiHooks(hooks)
+}
+
+type multiHooks []Hooks
+
+func (m multiHooks) StartCall(ctx context.Context, name string) (context.Context, func(error)) {
+	ends := make([]func(error), len(m))
+	for i, h := range m {
+		ctx, ends[i] = h.StartCall(ctx, name)
+	}
+	return ctx, func(err error) {
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](err)
+		}
+	}
+}
+
+func (m multiHooks) StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult)) {
+	ends := make([]func(RequestResult), len(m))
+	for i, h := range m {
+		ctx, ends[i] = h.StartRequest(ctx, info)
+	}
+	return ctx, func(result RequestResult) {
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](result)
+		}
+	}
+}
+
+func (m multiHooks) CacheLookup(ctx context.Context, resource string, hit bool) {
+	for _, h := range m {
+		h.CacheLookup(ctx, resource, hit)
+	}
+}
+
+// helper function to get the client's hooks, doing nothing when it has none
+func (c *Client) hooks() Hooks {
+	if c.Hooks != nil {
+		return c.Hooks
+	}
+	return NopHooks{}
+}
+
+// helper function to get the resource kind of request parameters, e.g.
+// pokemon/encounters for pokemon/25/encounters
+func resourceKind(parameters string) string {
+	parameters, _, _ = strings.Cut(parameters, "?")
+	parts := strings.Split(strings.Trim(parameters, "/"), "/")
+	if len(parts) > 2 {
+		return parts[0] + "/" + strings.Join(parts[2:], "/")
+	}
+	return parts[0]
+}
@@ -0,0 +1,116 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHooks records every event as a line of text.
+type recordingHooks struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (h *recordingHooks) record(event string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func (h *recordingHooks) StartCall(ctx context.Context, name string) (context.Context, func(error)) {
+	h.record("start " + name)
+	return ctx, func(err error) {
+		if err != nil {
+			h.record("end " + name + ": " + err.Error())
+			return
+		}
+		h.record("end " + name)
+	}
+}
+
+func (h *recordingHooks) StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult)) {
+	return ctx, func(result RequestResult) {
+		h.record(info.Method + " " + info.Resource + " " + http.StatusText(result.StatusCode))
+	}
+}
+
+func (h *recordingHooks) CacheLookup(ctx context.Context, resource string, hit bool) {
+	if hit {
+		h.record("cache hit " + resource)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon/pikachu":
+			json.NewEncoder(w).Encode(Pokemon{ID: 25, Name: "pikachu"})
+		case "/pokemon/pikachu/encounters":
+			json.NewEncoder(w).Encode([]LocationAreaEncounter{})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	hooks := &recordingHooks{}
+	client := &Client{
+		HTTPClient: server.Client(),
+		Endpoint:   server.URL,
+		Hooks:      hooks,
+		Cache:      NewMemoryCache(),
+		CacheTTL:   time.Minute,
+	}
+
+	_, err := client.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu", IncludeLocation: true})
+	require.NoError(t, err)
+	_, err = Get[Pokemon](context.Background(), client, "pikachu")
+	require.NoError(t, err)
+	_, err = client.GetStat(context.Background(), GetStatOpts{Name: "luck"})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Equal(t, []string{
+		"start pokemon.GetPokemon",
+		"GET pokemon OK",
+		"GET pokemon/encounters OK",
+		"end pokemon.GetPokemon",
+		"start pokemon.GetPokemon",
+		"cache hit pokemon",
+		"end pokemon.GetPokemon",
+		"start pokemon.GetStat",
+		"GET stat Not Found",
+		"end pokemon.GetStat: not found",
+	}, hooks.events)
+}
+
+func TestResourceKind(t *testing.T) {
+	tests := map[string]string{
+		"pokemon/25":            "pokemon",
+		"pokemon/25/encounters": "pokemon/encounters",
+		"pokemon/?limit=20":     "pokemon",
+		"berry-flavor/spicy":    "berry-flavor",
+	}
+	for parameters, expected := range tests {
+		require.Equal(t, expected, resourceKind(parameters), parameters)
+	}
+}
+
+func TestMultiHooks(t *testing.T) {
+	first, second := &recordingHooks{}, &recordingHooks{}
+	hooks := MultiHooks(first, second)
+
+	_, end := hooks.StartCall(context.Background(), "pokemon.GetNature")
+	hooks.CacheLookup(context.Background(), "nature", true)
+	end(nil)
+
+	expected := []string{"start pokemon.GetNature", "cache hit nature", "end pokemon.GetNature"}
+	require.Equal(t, expected, first.events)
+	require.Equal(t, expected, second.events)
+}
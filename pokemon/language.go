@@ -0,0 +1,96 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// LocalizedName is a resource name in a specific language.
+type LocalizedName struct {
+	Name     string   `json:"name"`
+	Language NamedURL `json:"language"`
+}
+
+// NatureName is the original name of LocalizedName, kept for compatibility.
+type NatureName = LocalizedName
+
+type EffectEntry struct {
+	Effect   string   `json:"effect"`
+	Language NamedURL `json:"language"`
+}
+
+type FlavorTextEntry struct {
+	FlavorText string   `json:"flavor_text"`
+	Language   NamedURL `json:"language"`
+}
+
+// LocalizedNames is the list of names a resource has across languages.
+type LocalizedNames []LocalizedName
+
+// EffectEntries is the list of effect descriptions a resource has across languages.
+type EffectEntries []EffectEntry
+
+// FlavorTextEntries is the list of flavor texts a resource has across languages.
+type FlavorTextEntries []FlavorTextEntry
+
+// Language represents a language resources can be localized in.
+type Language struct {
+	ID       int                        `json:"id"`
+	Name     string                     `json:"name"`
+	Official bool                       `json:"official"`
+	ISO639   string                     `json:"iso639"`
+	ISO3166  string                     `json:"iso3166"`
+	Names    LocalizedNames             `json:"names"`
+	Extra    map[string]json.RawMessage `json:"-"`
+}
+
+// GetLanguageOpts contains options for GetLanguage function.
+type GetLanguageOpts struct {
+	// ID is the ID of the Language to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the Language to retrieve, such as "en" or "ja-Hrkt".
+	Name string
+}
+
+// GetLanguage gets a language by ID or Name.
+func (c *Client) GetLanguage(ctx context.Context, opts GetLanguageOpts) (Language, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return Language{}, err
+	}
+	return Get[Language](ctx, c, lookupValue)
+}
+
+// NameIn returns the name in lang, or in the first fallback language that has
+// one. It returns an empty string if none of the languages match.
+func (n LocalizedNames) NameIn(lang string, fallback ...string) string {
+	return pickLocalized(n, func(entry LocalizedName) (string, string) {
+		return entry.Language.Name, entry.Name
+	}, lang, fallback)
+}
+
+// EffectIn returns the effect in lang, falling back like LocalizedNames.NameIn.
+func (e EffectEntries) EffectIn(lang string, fallback ...string) string {
+	return pickLocalized(e, func(entry EffectEntry) (string, string) {
+		return entry.Language.Name, entry.Effect
+	}, lang, fallback)
+}
+
+// FlavorTextIn returns the flavor text in lang, falling back like LocalizedNames.NameIn.
+func (f FlavorTextEntries) FlavorTextIn(lang string, fallback ...string) string {
+	return pickLocalized(f, func(entry FlavorTextEntry) (string, string) {
+		return entry.Language.Name, entry.FlavorText
+	}, lang, fallback)
+}
+
+// helper function to pick the first entry matching lang, then each fallback in turn
+func pickLocalized[T any](entries []T, split func(T) (string, string), lang string, fallback []string) string {
+	for _, want := range append([]string{lang}, fallback...) {
+		for _, entry := range entries {
+			if language, text := split(entry); language == want {
+				return text
+			}
+		}
+	}
+	return ""
+}
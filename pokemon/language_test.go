@@ -0,0 +1,85 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLanguage(t *testing.T) {
+	expected := Language{
+		ID:       9,
+		Name:     "en",
+		Official: true,
+		ISO639:   "en",
+		ISO3166:  "us",
+		Names: LocalizedNames{
+			{Name: "English", Language: NamedURL{Name: "en", URL: "/language/9"}},
+			{Name: "Anglais", Language: NamedURL{Name: "fr", URL: "/language/5"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/language/en" {
+			http.Error(w, "Language not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	language, err := client.GetLanguage(context.Background(), GetLanguageOpts{Name: "en"})
+	require.NoError(t, err)
+	require.Equal(t, expected, language)
+
+	_, err = client.GetLanguage(context.Background(), GetLanguageOpts{Name: "unknown"})
+	require.Error(t, err)
+}
+
+func TestNameIn(t *testing.T) {
+	names := LocalizedNames{
+		{Name: "Hardi", Language: NamedURL{Name: "fr"}},
+		{Name: "Hardy", Language: NamedURL{Name: "en"}},
+		{Name: "がんばりや", Language: NamedURL{Name: "ja-Hrkt"}},
+	}
+
+	tests := []struct {
+		scenario string
+		lang     string
+		fallback []string
+		expected string
+	}{
+		{scenario: "Requested language", lang: "fr", expected: "Hardi"},
+		{scenario: "First matching fallback", lang: "de", fallback: []string{"es", "en", "fr"}, expected: "Hardy"},
+		{scenario: "No match", lang: "de", fallback: []string{"es"}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			require.Equal(t, tt.expected, names.NameIn(tt.lang, tt.fallback...))
+		})
+	}
+}
+
+func TestLocalizedEntries(t *testing.T) {
+	effects := EffectEntries{
+		{Effect: "Gives a high number of appeal points.", Language: NamedURL{Name: "en"}},
+	}
+	require.Equal(t, "Gives a high number of appeal points.", effects.EffectIn("fr", "en"))
+
+	flavorTexts := FlavorTextEntries{
+		{FlavorText: "Un coup très attrayant.", Language: NamedURL{Name: "fr"}},
+	}
+	require.Equal(t, "Un coup très attrayant.", flavorTexts.FlavorTextIn("fr"))
+	require.Empty(t, flavorTexts.FlavorTextIn("en"))
+
+	contestNames := ContestNames{
+		{Name: "Cool", Color: "Red", Language: NamedURL{Name: "en"}},
+	}
+	require.Equal(t, "Cool", contestNames.NameIn("en"))
+}
@@ -0,0 +1,57 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ResourceList is one page of a list endpoint.
+type ResourceList struct {
+	Count    int        `json:"count"`
+	Next     string     `json:"next"`
+	Previous string     `json:"previous"`
+	Results  []NamedURL `json:"results"`
+}
+
+// ListOpts contains options for the List functions.
+type ListOpts struct {
+	// Limit is the number of results per page. The API defaults to 20.
+	Limit int
+	// Offset is the index of the first result to return.
+	Offset int
+}
+
+// ListResources gets one page of the list endpoint at path, such as "pokemon".
+func (c *Client) ListResources(ctx context.Context, path string, opts ListOpts) (list ResourceList, err error) {
+	ctx, end := c.hooks().StartCall(ctx, "pokemon.ListResources")
+	defer func() { end(err) }()
+
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		query.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	parameters := strings.Trim(path, "/") + "/"
+	if len(query) > 0 {
+		parameters += "?" + query.Encode()
+	}
+	err = fetchAndUnmarshal(ctx, c, parameters, &list)
+	return list, err
+}
+
+// List gets one page of the list endpoint registered for T. A nil client uses DefaultClient.
+func List[T Resource](ctx context.Context, c *Client, opts ListOpts) (ResourceList, error) {
+	if c == nil {
+		c = DefaultClient
+	}
+	path, ok := ResourcePath[T]()
+	if !ok {
+		return ResourceList{}, fmt.Errorf("no endpoint registered for %s", typeOf[T]())
+	}
+	return c.ListResources(ctx, path, opts)
+}
@@ -0,0 +1,73 @@
+package pokemon
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// helper function to send req through the middleware chain and hooks,
+// logging when it starts and how it ends
+func (c *Client) do(req *http.Request, resource string) (*http.Response, error) {
+	return c.send(c.doer(), req, resource)
+}
+
+// helper function to send req with doer through the hooks, logging when it
+// starts and how it ends
+func (c *Client) send(doer Doer, req *http.Request, resource string) (*http.Response, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	ctx := req.Context()
+	logger := c.logger()
+	info := RequestInfo{Method: req.Method, URL: req.URL.String(), Resource: resource}
+	logger.LogAttrs(ctx, slog.LevelDebug, "request started",
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()))
+
+	reqCtx, end := c.hooks().StartRequest(ctx, info)
+	start := time.Now()
+	resp, err := doer.Do(req.WithContext(reqCtx))
+	latency := time.Since(start)
+	result := RequestResult{Err: err, Duration: latency}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+	end(result)
+
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "request failed",
+			slog.String("url", req.URL.String()),
+			slog.Duration("latency", latency),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	level := slog.LevelDebug
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		level = slog.LevelWarn
+	}
+	logger.LogAttrs(ctx, level, "request finished",
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Int("status", resp.StatusCode),
+		slog.Duration("latency", latency))
+	return resp, nil
+}
+
+// helper function to get the client's logger, discarding events when it has none
+func (c *Client) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return discardLogger
+}
+
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,136 @@
+package pokemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// logEvents decodes the JSON lines written by a slog.JSONHandler.
+func logEvents(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var events []map[string]any
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var event map[string]any
+		require.NoError(t, decoder.Decode(&event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func eventsNamed(events []map[string]any, msg string) []map[string]any {
+	var matched []map[string]any
+	for _, event := range events {
+		if event["msg"] == msg {
+			matched = append(matched, event)
+		}
+	}
+	return matched
+}
+
+func TestRequestLogging(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(Nature{ID: 1, Name: "hardy"})
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := &Client{
+		HTTPClient: server.Client(),
+		Endpoint:   server.URL,
+		Logger:     slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+	require.ErrorContains(t, err, "unexpected status code: 503")
+	nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+	require.NoError(t, err)
+	require.Equal(t, "hardy", nature.Name)
+	require.Equal(t, int32(2), calls.Load(), "failed requests are not retried")
+
+	events := logEvents(t, &logs)
+	require.Len(t, eventsNamed(events, "request started"), 2)
+	finished := eventsNamed(events, "request finished")
+	require.Len(t, finished, 2)
+	require.EqualValues(t, http.StatusServiceUnavailable, finished[0]["status"])
+	require.Equal(t, "WARN", finished[0]["level"])
+	require.EqualValues(t, http.StatusOK, finished[1]["status"])
+	require.Equal(t, "DEBUG", finished[1]["level"])
+	require.Contains(t, finished[1], "latency")
+
+	t.Run("Network errors", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		var logs bytes.Buffer
+		client := &Client{
+			HTTPClient: closed.Client(),
+			Endpoint:   closed.URL,
+			Logger:     slog.New(slog.NewJSONHandler(&logs, nil)),
+		}
+		_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		require.Error(t, err)
+
+		failed := eventsNamed(logEvents(t, &logs), "request failed")
+		require.Len(t, failed, 1)
+		require.Equal(t, "WARN", failed[0]["level"])
+		require.Equal(t, closed.URL+"/nature/hardy", failed[0]["url"])
+		require.Contains(t, failed[0], "error")
+	})
+}
+
+func TestLoggingEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nature/broken" {
+			w.Write([]byte(`{"id": "one"}`))
+			return
+		}
+		w.Header().Set("Cache-Control", "max-age=60")
+		json.NewEncoder(w).Encode(Nature{ID: 1, Name: "hardy"})
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := &Client{
+		HTTPClient: server.Client(),
+		Endpoint:   server.URL,
+		Cache:      NewMemoryCache(),
+		Logger:     slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+	}
+	_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "broken"})
+	require.Error(t, err)
+
+	events := logEvents(t, &logs)
+	hits := eventsNamed(events, "cache hit")
+	require.Len(t, hits, 1)
+	require.Equal(t, server.URL+"/nature/hardy", hits[0]["url"])
+
+	failures := eventsNamed(events, "decode failed")
+	require.Len(t, failures, 1)
+	require.Equal(t, "ERROR", failures[0]["level"])
+	require.Equal(t, "nature/broken", failures[0]["path"])
+	require.Equal(t, "pokemon.Nature", failures[0]["type"])
+}
+
+func TestNoLoggerIsSilent(t *testing.T) {
+	client := &Client{}
+	require.False(t, client.logger().Enabled(context.Background(), slog.LevelError))
+}
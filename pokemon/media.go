@@ -0,0 +1,107 @@
+package pokemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// helper function to stream the file at rawURL to w through the client's
+// hooks, limited to MaxBodySize like API responses. The middleware only
+// applies when rawURL is on the client's Endpoint, so headers such as
+// credentials for a private mirror aren't sent to other hosts.
+// With a Cache, files are cached by URL like API responses, and a stale
+// entry is served when the request fails so media stays available offline.
+func (c *Client) download(ctx context.Context, rawURL, resource string, w io.Writer) (int64, error) {
+	var cached CacheEntry
+	var hasCached bool
+	if c.Cache != nil {
+		cached, hasCached = c.Cache.Get(rawURL)
+		hit := hasCached && cached.Fresh(time.Now())
+		c.hooks().CacheLookup(ctx, resource, hit)
+		if hit {
+			c.logger().LogAttrs(ctx, slog.LevelDebug, "cache hit", slog.String("url", rawURL))
+			n, err := w.Write(cached.Body)
+			return int64(n), err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	if hasCached {
+		cached.setValidators(req.Header)
+	}
+	resp, err := c.send(c.downloadDoer(req.URL), req, resource)
+	if err != nil {
+		if hasCached && ctx.Err() == nil {
+			c.logger().LogAttrs(ctx, slog.LevelWarn, "serving stale cache entry",
+				slog.String("url", rawURL),
+				slog.String("error", err.Error()))
+			n, err := w.Write(cached.Body)
+			return int64(n), err
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		c.logger().LogAttrs(ctx, slog.LevelDebug, "cache revalidated", slog.String("url", rawURL))
+		c.Cache.Set(rawURL, cached.revalidated(resp.Header, c.CacheTTL, time.Now()))
+		n, err := w.Write(cached.Body)
+		return int64(n), err
+	}
+	if err := checkStatus(resp); err != nil {
+		return 0, err
+	}
+
+	// Stream to w, keeping a copy for the cache
+	var buf *bytes.Buffer
+	dest := w
+	if c.Cache != nil && cacheable(resp.Header) {
+		buf = new(bytes.Buffer)
+		dest = io.MultiWriter(w, buf)
+	}
+	n, err := copyBody(c, dest, resp.Body, resp.ContentLength)
+	if err != nil {
+		return n, err
+	}
+	if buf != nil {
+		c.Cache.Set(rawURL, newCacheEntry(buf.Bytes(), resp.Header, c.CacheTTL, time.Now()))
+	}
+	return n, nil
+}
+
+// helper function to get the doer for downloading u, with the middleware
+// chain only when u has the same origin as the client's Endpoint
+func (c *Client) downloadDoer(u *url.URL) Doer {
+	endpoint, err := url.Parse(c.Endpoint)
+	if err == nil && strings.EqualFold(endpoint.Scheme, u.Scheme) && strings.EqualFold(endpoint.Host, u.Host) {
+		return c.doer()
+	}
+	return c.HTTPClient
+}
+
+// helper function to copy a response body to w, failing once it exceeds the
+// client's MaxBodySize
+func copyBody(c *Client, w io.Writer, body io.Reader, contentLength int64) (int64, error) {
+	limit := c.bodyLimit()
+	if limit < 0 {
+		return io.Copy(w, body)
+	}
+	if contentLength > limit {
+		return 0, fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrBodyTooLarge, contentLength, limit)
+	}
+	n, err := io.Copy(w, io.LimitReader(body, limit+1))
+	if err == nil && n > limit {
+		err = fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, limit)
+	}
+	return n, err
+}
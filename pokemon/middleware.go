@@ -0,0 +1,112 @@
+package pokemon
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Doer sends an HTTP request. *http.Client is a Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer, e.g. to add headers or log requests.
+type Middleware func(next Doer) Doer
+
+// Use appends middleware to the client's chain.
+func (c *Client) Use(middleware ...Middleware) {
+	c.Middleware = append(c.Middleware, middleware...)
+}
+
+// helper function to wrap HTTPClient in the middleware chain
+func (c *Client) doer() Doer {
+	var doer Doer = c.HTTPClient
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		doer = c.Middleware[i](doer)
+	}
+	return doer
+}
+
+// UserAgent returns middleware that sets the User-Agent header.
+func UserAgent(userAgent string) Middleware {
+	return Headers(http.Header{"User-Agent": {userAgent}})
+}
+
+// Headers returns middleware that sets the given headers on every request,
+// e.g. an Authorization header for a private mirror.
+func Headers(header http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for name, values := range header {
+				req.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+type requestIDKey struct{}
+
+// RequestIDHeader is the header the RequestID middleware sets.
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID returns middleware that gives every request a random ID in the
+// X-Request-Id header, unless it already has one. The ID is also available
+// to later middleware through RequestIDFromContext.
+func RequestID() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			id := req.Header.Get(RequestIDHeader)
+			if id == "" {
+				buf := make([]byte, 8)
+				if _, err := rand.Read(buf); err != nil {
+					return nil, err
+				}
+				id = hex.EncodeToString(buf)
+			}
+			req = req.Clone(context.WithValue(req.Context(), requestIDKey{}, id))
+			req.Header.Set(RequestIDHeader, id)
+			return next.Do(req)
+		})
+	}
+}
+
+// RequestIDFromContext returns the ID set by the RequestID middleware.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// Logging returns middleware that logs every request with its status and
+// duration to logger.
+func Logging(logger *log.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			prefix := req.Method + " " + req.URL.String()
+			if id, ok := RequestIDFromContext(req.Context()); ok {
+				prefix += " [" + id + "]"
+			}
+			if err != nil {
+				logger.Printf("%s failed after %s: %v", prefix, time.Since(start), err)
+				return resp, err
+			}
+			logger.Printf("%s %d in %s", prefix, resp.StatusCode, time.Since(start))
+			return resp, nil
+		})
+	}
+}
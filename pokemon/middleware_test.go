@@ -0,0 +1,79 @@
+package pokemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		if r.URL.Path != "/nature/hardy" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(Nature{ID: 1, Name: "hardy"})
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+	client.Use(
+		trace("outer"),
+		UserAgent("pokedex-app/1.0"),
+		Headers(http.Header{"Authorization": {"Bearer mirror-token"}}),
+		RequestID(),
+		Logging(log.New(&logs, "", 0)),
+		trace("inner"),
+	)
+
+	_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"outer", "inner"}, order)
+	require.Equal(t, "pokedex-app/1.0", received.Get("User-Agent"))
+	require.Equal(t, "Bearer mirror-token", received.Get("Authorization"))
+	id := received.Get(RequestIDHeader)
+	require.Len(t, id, 16)
+	require.Contains(t, logs.String(), "GET "+server.URL+"/nature/hardy ["+id+"] 200 in ")
+
+	t.Run("Every request gets a new ID", func(t *testing.T) {
+		_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "unknown"})
+		require.ErrorIs(t, err, ErrNotFound)
+		require.NotEqual(t, id, received.Get(RequestIDHeader))
+		require.Contains(t, logs.String(), "/nature/unknown")
+	})
+}
+
+func TestRequestIDKeepsExistingID(t *testing.T) {
+	var seen string
+	doer := RequestID()(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		seen, _ = RequestIDFromContext(req.Context())
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+	req.Header.Set(RequestIDHeader, "caller-id")
+	_, err = doer.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, "caller-id", seen)
+}
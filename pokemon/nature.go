@@ -0,0 +1,108 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Modifier returns the multiplier the nature applies to a stat, such as
+// "attack": 1.1 for its increased stat, 0.9 for its decreased stat and 1
+// otherwise.
+func (n Nature) Modifier(stat string) float64 {
+	return float64(natureModifier(n, stat)) / 100
+}
+
+// IsNeutral reports whether the nature changes no stats, either because it
+// has no increased and decreased stat or because they are the same.
+func (n Nature) IsNeutral() bool {
+	return n.IncreasedStat.Name == n.DecreasedStat.Name
+}
+
+// BattleRole is the job a Pokémon has in a team, used to recommend natures.
+type BattleRole string
+
+const (
+	RolePhysicalSweeper BattleRole = "physical-sweeper"
+	RoleSpecialSweeper  BattleRole = "special-sweeper"
+	RoleMixedSweeper    BattleRole = "mixed-sweeper"
+	RolePhysicalWall    BattleRole = "physical-wall"
+	RoleSpecialWall     BattleRole = "special-wall"
+	// RoleTrickRoom is a slow attacker for Trick Room, which wants less Speed.
+	RoleTrickRoom BattleRole = "trick-room"
+)
+
+// roleWeights holds how much each role values each stat a nature can change.
+// Stats a role doesn't use weigh 0, so they are the best ones to lower, and a
+// negative weight means the role wants the stat lower.
+var roleWeights = map[BattleRole]map[string]float64{
+	RolePhysicalSweeper: {StatAttack: 1, StatSpeed: 1, StatDefense: 0.25, StatSpecialDefense: 0.25},
+	RoleSpecialSweeper:  {StatSpecialAttack: 1, StatSpeed: 1, StatDefense: 0.25, StatSpecialDefense: 0.25},
+	RoleMixedSweeper:    {StatAttack: 1, StatSpecialAttack: 1, StatSpeed: 1, StatDefense: 0.25, StatSpecialDefense: 0.25},
+	RolePhysicalWall:    {StatDefense: 1, StatSpecialDefense: 0.5, StatSpeed: 0.25, StatAttack: 0.1, StatSpecialAttack: 0.1},
+	RoleSpecialWall:     {StatSpecialDefense: 1, StatDefense: 0.5, StatSpeed: 0.25, StatAttack: 0.1, StatSpecialAttack: 0.1},
+	RoleTrickRoom:       {StatAttack: 1, StatSpecialAttack: 1, StatSpeed: -1, StatDefense: 0.25, StatSpecialDefense: 0.25},
+}
+
+// NatureScore is a nature ranked by RankNatures.
+type NatureScore struct {
+	Nature Nature
+	// Score is how much the nature helps the role, weighing the base stats it
+	// raises and lowers by how much the role uses them. Neutral natures score 0.
+	Score float64
+}
+
+// RankNatures ranks natures by how well they suit a Pokémon with the given
+// base stats in a role, best first. Natures with equal scores are ordered by
+// name.
+func RankNatures(base StatSet, role BattleRole, natures []Nature) ([]NatureScore, error) {
+	weights, ok := roleWeights[role]
+	if !ok {
+		return nil, fmt.Errorf("unknown battle role %q", role)
+	}
+
+	scores := make([]NatureScore, 0, len(natures))
+	for _, nature := range natures {
+		var score float64
+		for _, stat := range battleStats[1:] {
+			value, _ := base.Get(stat)
+			score += float64((natureModifier(nature, stat)-100)*value) / 100 * weights[stat]
+		}
+		scores = append(scores, NatureScore{Nature: nature, Score: score})
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Nature.Name < scores[j].Nature.Name
+	})
+	return scores, nil
+}
+
+// RecommendNatures fetches every nature and ranks them with RankNatures for
+// the Pokémon's base stats in a role.
+func (c *Client) RecommendNatures(ctx context.Context, pokemon Pokemon, role BattleRole) (scores []NatureScore, err error) {
+	ctx, end := c.hooks().StartCall(ctx, "pokemon.RecommendNatures")
+	defer func() { end(err) }()
+
+	if _, ok := roleWeights[role]; !ok {
+		return nil, fmt.Errorf("unknown battle role %q", role)
+	}
+	base, err := pokemon.BaseStats()
+	if err != nil {
+		return nil, err
+	}
+	refs, err := listAll(ctx, c, "nature")
+	if err != nil {
+		return nil, fmt.Errorf("error listing natures: %w", err)
+	}
+	natures := make([]Nature, 0, len(refs))
+	for _, ref := range refs {
+		nature, err := Resolve[Nature](ctx, c, ref)
+		if err != nil {
+			return nil, fmt.Errorf("error getting nature %s: %w", ref.Name, err)
+		}
+		natures = append(natures, nature)
+	}
+	return RankNatures(base, role, natures)
+}
@@ -0,0 +1,112 @@
+package pokemon
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newNature(name, increased, decreased string) Nature {
+	return Nature{Name: name, IncreasedStat: NamedURL{Name: increased}, DecreasedStat: NamedURL{Name: decreased}}
+}
+
+var testNatures = []Nature{
+	newNature("hardy", "", ""),
+	newNature("docile", StatDefense, StatDefense),
+	newNature("adamant", StatAttack, StatSpecialAttack),
+	newNature("jolly", StatSpeed, StatSpecialAttack),
+	newNature("brave", StatAttack, StatSpeed),
+	newNature("modest", StatSpecialAttack, StatAttack),
+	newNature("bold", StatDefense, StatAttack),
+	newNature("impish", StatDefense, StatSpecialAttack),
+	newNature("careful", StatSpecialDefense, StatSpecialAttack),
+}
+
+func TestNatureModifier(t *testing.T) {
+	adamant := newNature("adamant", StatAttack, StatSpecialAttack)
+	require.Equal(t, 1.1, adamant.Modifier(StatAttack))
+	require.Equal(t, 0.9, adamant.Modifier(StatSpecialAttack))
+	require.Equal(t, 1.0, adamant.Modifier(StatSpeed))
+	require.Equal(t, 1.0, adamant.Modifier(StatHP))
+	require.False(t, adamant.IsNeutral())
+
+	for _, neutral := range []Nature{newNature("hardy", "", ""), newNature("docile", StatDefense, StatDefense)} {
+		require.True(t, neutral.IsNeutral(), neutral.Name)
+		require.Equal(t, 1.0, neutral.Modifier(StatDefense), neutral.Name)
+	}
+}
+
+func TestRankNatures(t *testing.T) {
+	garchomp := StatSet{HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102}
+	skarmory := StatSet{HP: 65, Attack: 80, Defense: 140, SpecialAttack: 40, SpecialDefense: 70, Speed: 70}
+
+	tests := []struct {
+		name     string
+		base     StatSet
+		role     BattleRole
+		expected []string
+	}{
+		{name: "Physical sweeper", base: garchomp, role: RolePhysicalSweeper, expected: []string{"adamant", "jolly"}},
+		{name: "Special sweeper", base: garchomp, role: RoleSpecialSweeper, expected: []string{"modest", "bold"}},
+		{name: "Physical wall", base: skarmory, role: RolePhysicalWall, expected: []string{"impish", "bold"}},
+		{name: "Special wall", base: skarmory, role: RoleSpecialWall, expected: []string{"careful", "impish"}},
+		{name: "Trick Room", base: garchomp, role: RoleTrickRoom, expected: []string{"brave", "adamant"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores, err := RankNatures(tt.base, tt.role, testNatures)
+			require.NoError(t, err)
+			require.Len(t, scores, len(testNatures))
+			for i, name := range tt.expected {
+				require.Equal(t, name, scores[i].Nature.Name, "rank %d", i)
+			}
+			for i := 1; i < len(scores); i++ {
+				require.GreaterOrEqual(t, scores[i-1].Score, scores[i].Score)
+			}
+		})
+	}
+
+	t.Run("Neutral natures score zero", func(t *testing.T) {
+		scores, err := RankNatures(garchomp, RolePhysicalSweeper, []Nature{newNature("hardy", "", ""), newNature("docile", StatDefense, StatDefense)})
+		require.NoError(t, err)
+		require.Equal(t, "docile", scores[0].Nature.Name)
+		require.Zero(t, scores[0].Score)
+		require.Zero(t, scores[1].Score)
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		_, err := RankNatures(garchomp, "cleric", testNatures)
+		require.Error(t, err)
+	})
+}
+
+func TestRecommendNatures(t *testing.T) {
+	api := &fakeListAPI{resources: map[string][]Nature{}}
+	var natures []Nature
+	for i, nature := range testNatures {
+		nature.ID = i + 1
+		natures = append(natures, nature)
+	}
+	api.set("nature", natures...)
+	server := httptest.NewServer(api)
+	defer server.Close()
+	c := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/"}
+
+	garchomp := Pokemon{Name: "garchomp", StatDetails: statDetails(
+		StatHP, 108, StatAttack, 130, StatDefense, 95, StatSpecialAttack, 80, StatSpecialDefense, 85, StatSpeed, 102,
+	)}
+	scores, err := c.RecommendNatures(context.Background(), garchomp, RolePhysicalSweeper)
+	require.NoError(t, err)
+	require.Len(t, scores, len(testNatures))
+	require.Equal(t, "adamant", scores[0].Nature.Name)
+
+	_, err = c.RecommendNatures(context.Background(), Pokemon{Name: "missingno"}, RolePhysicalSweeper)
+	require.ErrorContains(t, err, "missingno has no hp stat")
+
+	// The role is checked first, so it is reported even without stats
+	_, err = c.RecommendNatures(context.Background(), Pokemon{Name: "missingno"}, "cleric")
+	require.ErrorContains(t, err, `unknown battle role "cleric"`)
+}
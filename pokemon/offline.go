@@ -0,0 +1,110 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SnapshotBackend is a Backend that serves requests from a snapshot written
+// by a Snapshotter, resolving names to IDs through the manifest.
+type SnapshotBackend struct {
+	dir      string
+	manifest SnapshotManifest
+	names    map[string]string
+}
+
+// OpenSnapshot opens the snapshot in dir, such as Snapshotter.Path().
+func OpenSnapshot(dir string) (*SnapshotBackend, error) {
+	manifest, err := ReadSnapshotManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	for key, entry := range manifest.Resources {
+		if entry.Name != "" {
+			names[key[:strings.Index(key, "/")+1]+entry.Name] = key
+		}
+	}
+	return &SnapshotBackend{dir: dir, manifest: manifest, names: names}, nil
+}
+
+// Manifest returns the manifest of the snapshot.
+func (b *SnapshotBackend) Manifest() SnapshotManifest {
+	return b.manifest
+}
+
+// Fetch reads the resource or list page at path from the snapshot.
+func (b *SnapshotBackend) Fetch(ctx context.Context, path string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) == 1 {
+		return b.list(parts[0], parsed.Query())
+	}
+
+	key := parts[0] + "/" + strings.ToLower(parts[1])
+	if _, ok := b.manifest.Resources[key]; !ok {
+		if resolved, ok := b.names[key]; ok {
+			key = resolved
+		}
+	}
+	if len(parts) > 2 {
+		key += "/" + strings.Join(parts[2:], "/")
+	}
+	entry, ok := b.manifest.Resources[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return os.ReadFile(filepath.Join(b.dir, filepath.FromSlash(entry.File)))
+}
+
+// helper function to build a list page from the lists recorded in the manifest
+func (b *SnapshotBackend) list(path string, query url.Values) ([]byte, error) {
+	results, ok := b.manifest.Lists[path]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	var err error
+	limit, offset := 20, 0
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid limit %q", value)
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid offset %q", value)
+		}
+	}
+	if offset > len(results) {
+		offset = len(results)
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+
+	page := ResourceList{Count: len(results), Results: results[offset:end]}
+	if end < len(results) {
+		page.Next = path + "/?limit=" + strconv.Itoa(limit) + "&offset=" + strconv.Itoa(end)
+	}
+	if offset > 0 {
+		previous := offset - limit
+		if previous < 0 {
+			previous = 0
+		}
+		page.Previous = path + "/?limit=" + strconv.Itoa(limit) + "&offset=" + strconv.Itoa(previous)
+	}
+	return json.Marshal(page)
+}
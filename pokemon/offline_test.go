@@ -0,0 +1,183 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotPaths writes a snapshot of the given request paths, fetched over
+// the network with client, without walking any list endpoints.
+func snapshotPaths(t *testing.T, client *Client, paths ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	manifest := SnapshotManifest{
+		Version:   filepath.Base(dir),
+		Endpoint:  client.Endpoint,
+		Complete:  true,
+		Lists:     map[string][]NamedURL{},
+		Resources: map[string]SnapshotEntry{},
+	}
+	for _, path := range paths {
+		body, err := fetchHTTP(context.Background(), client, path)
+		require.NoError(t, err)
+		var ref struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		require.NoError(t, json.Unmarshal(body, &ref))
+
+		kind := path[:strings.Index(path, "/")]
+		key := kind + "/" + strconv.Itoa(ref.ID)
+		file := key + ".json"
+		require.NoError(t, writeFileAtomic(filepath.Join(dir, file), body))
+		manifest.Resources[key] = SnapshotEntry{Name: ref.Name, File: file, SHA256: checksum(body)}
+		manifest.Lists[kind] = append(manifest.Lists[kind], NamedURL{Name: ref.Name, URL: "/" + key + "/"})
+	}
+	require.NoError(t, writeSnapshotManifest(dir, manifest))
+	return dir
+}
+
+// forEachBackend runs fn against DefaultClient over the network, then again
+// with DefaultClient reading offline from a snapshot of paths.
+func forEachBackend(t *testing.T, paths []string, fn func(t *testing.T)) {
+	t.Run("Network", fn)
+
+	snapshot, err := OpenSnapshot(snapshotPaths(t, DefaultClient, paths...))
+	require.NoError(t, err)
+	DefaultClient.UseSnapshot(snapshot, OfflineOnly)
+	defer func() { DefaultClient.Backend = nil }()
+	t.Run("Offline", fn)
+}
+
+func TestSnapshotBackend(t *testing.T) {
+	api := &fakeListAPI{resources: map[string][]Nature{}}
+	api.set("nature", Nature{ID: 1, Name: "hardy"}, Nature{ID: 2, Name: "bold"}, Nature{ID: 3, Name: "modest"})
+	api.set("pokemon", Nature{ID: 25, Name: "pikachu"})
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	snapshotter := &Snapshotter{
+		Client: &Client{HTTPClient: server.Client(), Endpoint: server.URL},
+		Dir:    t.TempDir(),
+		Paths:  []string{"nature", "pokemon"},
+	}
+	_, err := snapshotter.Run(context.Background())
+	require.NoError(t, err)
+	snapshot, err := OpenSnapshot(snapshotter.Path())
+	require.NoError(t, err)
+	require.True(t, snapshot.Manifest().Complete)
+
+	// The endpoint is unreachable, so every request must come from the snapshot.
+	client := &Client{HTTPClient: http.DefaultClient, Endpoint: "http://127.0.0.1:0/"}
+	client.UseSnapshot(snapshot, OfflineOnly)
+
+	t.Run("By name and by ID", func(t *testing.T) {
+		nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "Bold"})
+		require.NoError(t, err)
+		require.Equal(t, 2, nature.ID)
+
+		nature, err = client.GetNature(context.Background(), GetNatureOpts{ID: 3})
+		require.NoError(t, err)
+		require.Equal(t, "modest", nature.Name)
+	})
+
+	t.Run("Sub-resource by name", func(t *testing.T) {
+		pokemon, err := client.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu", IncludeLocation: true})
+		require.NoError(t, err)
+		require.Equal(t, EncountersData("[]"), pokemon.LocationAreaEncounters)
+	})
+
+	t.Run("List pages", func(t *testing.T) {
+		page, err := client.ListResources(context.Background(), "nature", ListOpts{Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, 3, page.Count)
+		require.Len(t, page.Results, 2)
+		require.NotEmpty(t, page.Next)
+
+		page, err = client.ListResources(context.Background(), "nature", ListOpts{Limit: 2, Offset: 2})
+		require.NoError(t, err)
+		require.Equal(t, "modest", page.Results[0].Name)
+		require.Empty(t, page.Next)
+		require.NotEmpty(t, page.Previous)
+	})
+
+	t.Run("Invalid list query", func(t *testing.T) {
+		for _, query := range []string{"offset=-1", "limit=-1", "limit=2&offset=-5", "limit=two"} {
+			_, err := snapshot.Fetch(context.Background(), "nature/?"+query)
+			require.Error(t, err, query)
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "calm"})
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = client.ListResources(context.Background(), "stat", ListOpts{})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestOfflineModes(t *testing.T) {
+	status, id := http.StatusOK, 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		json.NewEncoder(w).Encode(Nature{ID: id, Name: strings.TrimPrefix(r.URL.Path, "/nature/")})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+	snapshot, err := OpenSnapshot(snapshotPaths(t, client, "nature/hardy"))
+	require.NoError(t, err)
+	// Resources served by the network from now on are distinguishable from the snapshot.
+	id = 99
+
+	t.Run("Offline first falls back to the network", func(t *testing.T) {
+		client.UseSnapshot(snapshot, OfflineFirst)
+		nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+		require.Equal(t, 1, nature.ID)
+
+		nature, err = client.GetNature(context.Background(), GetNatureOpts{Name: "calm"})
+		require.NoError(t, err)
+		require.Equal(t, 99, nature.ID)
+	})
+
+	t.Run("Network first falls back to the snapshot on failure", func(t *testing.T) {
+		client.UseSnapshot(snapshot, NetworkFirst)
+		status = http.StatusInternalServerError
+		defer func() { status = http.StatusOK }()
+
+		nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+		require.Equal(t, 1, nature.ID)
+
+		_, err = client.GetNature(context.Background(), GetNatureOpts{Name: "calm"})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Network first prefers the network", func(t *testing.T) {
+		client.UseSnapshot(snapshot, NetworkFirst)
+		nature, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+		require.Equal(t, 99, nature.ID)
+	})
+
+	t.Run("Network first does not fall back on not found", func(t *testing.T) {
+		client.UseSnapshot(snapshot, NetworkFirst)
+		status = http.StatusNotFound
+		defer func() { status = http.StatusOK }()
+
+		_, err := client.GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
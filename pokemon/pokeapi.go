@@ -2,19 +2,28 @@ package pokemon
 
 import (
 	"context"
-	"net/http"
+	"io"
 )
 
-var DefaultClient = &Client{
-	HTTPClient: http.DefaultClient,
-	Endpoint:   "https://pokeapi.co/api/v2/",
-}
+// DefaultClient is used by the package-level functions. Its endpoint is
+// selected by EnvBaseURL, defaulting to the public API.
+var DefaultClient = NewClient()
 
 // GetPokemon retrieves a Pokemon by its ID or name.
 func GetPokemon(ctx context.Context, opts GetPokemonOpts) (Pokemon, error) {
 	return DefaultClient.GetPokemon(ctx, opts)
 }
 
+// DownloadSprite writes a sprite of a Pokemon to w.
+func DownloadSprite(ctx context.Context, pokemon Pokemon, variant SpriteVariant, w io.Writer) error {
+	return DefaultClient.DownloadSprite(ctx, pokemon, variant, w)
+}
+
+// DownloadCry streams a cry of a Pokemon to w.
+func DownloadCry(ctx context.Context, pokemon Pokemon, variant CryVariant, w io.Writer) error {
+	return DefaultClient.DownloadCry(ctx, pokemon, variant, w)
+}
+
 // GetNature retrieves a Nature by its ID or name.
 func GetNature(ctx context.Context, opts GetNatureOpts) (Nature, error) {
 	return DefaultClient.GetNature(ctx, opts)
@@ -24,3 +33,83 @@ func GetNature(ctx context.Context, opts GetNatureOpts) (Nature, error) {
 func GetStat(ctx context.Context, opts GetStatOpts) (Stat, error) {
 	return DefaultClient.GetStat(ctx, opts)
 }
+
+// GetContestType retrieves a ContestType by its ID or name.
+func GetContestType(ctx context.Context, opts GetContestTypeOpts) (ContestType, error) {
+	return DefaultClient.GetContestType(ctx, opts)
+}
+
+// GetContestEffect retrieves a ContestEffect by its ID.
+func GetContestEffect(ctx context.Context, opts GetContestEffectOpts) (ContestEffect, error) {
+	return DefaultClient.GetContestEffect(ctx, opts)
+}
+
+// GetSuperContestEffect retrieves a SuperContestEffect by its ID.
+func GetSuperContestEffect(ctx context.Context, opts GetSuperContestEffectOpts) (SuperContestEffect, error) {
+	return DefaultClient.GetSuperContestEffect(ctx, opts)
+}
+
+// GetBerryFlavor retrieves a BerryFlavor by its ID or name.
+func GetBerryFlavor(ctx context.Context, opts GetBerryFlavorOpts) (BerryFlavor, error) {
+	return DefaultClient.GetBerryFlavor(ctx, opts)
+}
+
+// GetNatureContestConditions resolves a Nature's flavor preferences to contest conditions.
+func GetNatureContestConditions(ctx context.Context, nature Nature) (NatureContestConditions, error) {
+	return DefaultClient.GetNatureContestConditions(ctx, nature)
+}
+
+// GetPokeathlonStat retrieves a PokeathlonStat by its ID or name.
+func GetPokeathlonStat(ctx context.Context, opts GetPokeathlonStatOpts) (PokeathlonStat, error) {
+	return DefaultClient.GetPokeathlonStat(ctx, opts)
+}
+
+// GetMoveBattleStyle retrieves a MoveBattleStyle by its ID or name.
+func GetMoveBattleStyle(ctx context.Context, opts GetMoveBattleStyleOpts) (MoveBattleStyle, error) {
+	return DefaultClient.GetMoveBattleStyle(ctx, opts)
+}
+
+// GetCharacteristic retrieves a Characteristic by its ID.
+func GetCharacteristic(ctx context.Context, opts GetCharacteristicOpts) (Characteristic, error) {
+	return DefaultClient.GetCharacteristic(ctx, opts)
+}
+
+// GetCharacteristicText retrieves the characteristic text a Pokémon with the given IVs displays.
+func GetCharacteristicText(ctx context.Context, ivs StatSet, language string, fallback ...string) (string, error) {
+	return DefaultClient.GetCharacteristicText(ctx, ivs, language, fallback...)
+}
+
+// GetPokemonForm retrieves a PokemonForm by its ID or name.
+func GetPokemonForm(ctx context.Context, opts GetPokemonFormOpts) (PokemonForm, error) {
+	return DefaultClient.GetPokemonForm(ctx, opts)
+}
+
+// GetPokemonColor retrieves a PokemonColor by its ID or name.
+func GetPokemonColor(ctx context.Context, opts GetPokemonColorOpts) (PokemonColor, error) {
+	return DefaultClient.GetPokemonColor(ctx, opts)
+}
+
+// GetPokemonShape retrieves a PokemonShape by its ID or name.
+func GetPokemonShape(ctx context.Context, opts GetPokemonShapeOpts) (PokemonShape, error) {
+	return DefaultClient.GetPokemonShape(ctx, opts)
+}
+
+// GetPokemonHabitat retrieves a PokemonHabitat by its ID or name.
+func GetPokemonHabitat(ctx context.Context, opts GetPokemonHabitatOpts) (PokemonHabitat, error) {
+	return DefaultClient.GetPokemonHabitat(ctx, opts)
+}
+
+// FilterSpecies retrieves the Pokémon species matching a color, shape and/or habitat.
+func FilterSpecies(ctx context.Context, filter SpeciesFilter) ([]NamedURL, error) {
+	return DefaultClient.FilterSpecies(ctx, filter)
+}
+
+// GetLanguage retrieves a Language by its ID or name.
+func GetLanguage(ctx context.Context, opts GetLanguageOpts) (Language, error) {
+	return DefaultClient.GetLanguage(ctx, opts)
+}
+
+// RecommendNatures ranks every nature by how well it suits a Pokemon in a role.
+func RecommendNatures(ctx context.Context, pokemon Pokemon, role BattleRole) ([]NatureScore, error) {
+	return DefaultClient.RecommendNatures(ctx, pokemon, role)
+}
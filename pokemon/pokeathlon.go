@@ -0,0 +1,42 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type NaturePokeathlonStatAffect struct {
+	MaxChange int      `json:"max_change"`
+	Nature    NamedURL `json:"nature"`
+}
+
+type NaturePokeathlonStatAffectSets struct {
+	Increase []NaturePokeathlonStatAffect `json:"increase"`
+	Decrease []NaturePokeathlonStatAffect `json:"decrease"`
+}
+
+// PokeathlonStat represents a stat used in the Pokéathlon, such as speed or jump.
+type PokeathlonStat struct {
+	ID               int                            `json:"id"`
+	Name             string                         `json:"name"`
+	AffectingNatures NaturePokeathlonStatAffectSets `json:"affecting_natures"`
+	Names            LocalizedNames                 `json:"names"`
+	Extra            map[string]json.RawMessage     `json:"-"`
+}
+
+// GetPokeathlonStatOpts contains options for GetPokeathlonStat function.
+type GetPokeathlonStatOpts struct {
+	// ID is the ID of the PokeathlonStat to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the PokeathlonStat to retrieve.
+	Name string
+}
+
+// GetPokeathlonStat gets a Pokéathlon stat by ID or Name.
+func (c *Client) GetPokeathlonStat(ctx context.Context, opts GetPokeathlonStatOpts) (PokeathlonStat, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return PokeathlonStat{}, err
+	}
+	return Get[PokeathlonStat](ctx, c, lookupValue)
+}
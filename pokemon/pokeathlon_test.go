@@ -0,0 +1,76 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPokeathlonStat(t *testing.T) {
+	tests := []struct {
+		scenario string
+		statName string
+		expected PokeathlonStat
+		err      bool
+	}{
+		{
+			scenario: "Successful retrieval of a pokeathlon stat",
+			statName: "speed",
+			expected: PokeathlonStat{
+				ID:   1,
+				Name: "speed",
+				AffectingNatures: NaturePokeathlonStatAffectSets{
+					Increase: []NaturePokeathlonStatAffect{
+						{MaxChange: 2, Nature: NamedURL{Name: "timid", URL: "/nature/5"}},
+					},
+					Decrease: []NaturePokeathlonStatAffect{
+						{MaxChange: -1, Nature: NamedURL{Name: "brave", URL: "/nature/2"}},
+					},
+				},
+				Names: []NatureName{
+					{Name: "Speed", Language: NamedURL{Name: "en", URL: "/language/9"}},
+				},
+			},
+			err: false,
+		},
+		{
+			scenario: "Pokeathlon stat not found",
+			statName: "unknown",
+			expected: PokeathlonStat{},
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.err {
+					http.Error(w, "Pokeathlon stat not found", http.StatusNotFound)
+					return
+				}
+				require.Equal(t, "/pokeathlon-stat/"+tt.statName, r.URL.Path)
+				json.NewEncoder(w).Encode(tt.expected)
+			}))
+			defer server.Close()
+
+			client := &Client{
+				HTTPClient: server.Client(),
+				Endpoint:   server.URL,
+			}
+
+			stat, err := client.GetPokeathlonStat(context.Background(), GetPokeathlonStatOpts{
+				Name: tt.statName,
+			})
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, stat)
+		})
+	}
+}
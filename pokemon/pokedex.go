@@ -0,0 +1,162 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// PokemonColor represents a color used to sort Pokémon in a Pokédex.
+type PokemonColor struct {
+	ID             int                        `json:"id"`
+	Name           string                     `json:"name"`
+	Names          LocalizedNames             `json:"names"`
+	PokemonSpecies []NamedURL                 `json:"pokemon_species"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+type AwesomeName struct {
+	AwesomeName string   `json:"awesome_name"`
+	Language    NamedURL `json:"language"`
+}
+
+// AwesomeNames is the list of scientific names a shape has across languages.
+type AwesomeNames []AwesomeName
+
+// PokemonShape represents a body shape used to sort Pokémon in a Pokédex.
+type PokemonShape struct {
+	ID             int                        `json:"id"`
+	Name           string                     `json:"name"`
+	AwesomeNames   AwesomeNames               `json:"awesome_names"`
+	Names          LocalizedNames             `json:"names"`
+	PokemonSpecies []NamedURL                 `json:"pokemon_species"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+// PokemonHabitat represents a habitat Pokémon can be found in.
+type PokemonHabitat struct {
+	ID             int                        `json:"id"`
+	Name           string                     `json:"name"`
+	Names          LocalizedNames             `json:"names"`
+	PokemonSpecies []NamedURL                 `json:"pokemon_species"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+// GetPokemonColorOpts contains options for GetPokemonColor function.
+type GetPokemonColorOpts struct {
+	// ID is the ID of the PokemonColor to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the PokemonColor to retrieve.
+	Name string
+}
+
+// GetPokemonShapeOpts contains options for GetPokemonShape function.
+type GetPokemonShapeOpts struct {
+	// ID is the ID of the PokemonShape to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the PokemonShape to retrieve.
+	Name string
+}
+
+// GetPokemonHabitatOpts contains options for GetPokemonHabitat function.
+type GetPokemonHabitatOpts struct {
+	// ID is the ID of the PokemonHabitat to retrieve. Only name or ID needs to be included.
+	ID int
+	// Name is the name of the PokemonHabitat to retrieve.
+	Name string
+}
+
+// SpeciesFilter contains the criteria for FilterSpecies. Empty fields are ignored.
+type SpeciesFilter struct {
+	// Color is the name of a PokemonColor, such as "red".
+	Color string
+	// Shape is the name of a PokemonShape, such as "wings".
+	Shape string
+	// Habitat is the name of a PokemonHabitat, such as "forest".
+	Habitat string
+}
+
+// NameIn returns the awesome name in lang, falling back like LocalizedNames.NameIn.
+func (a AwesomeNames) NameIn(lang string, fallback ...string) string {
+	return pickLocalized(a, func(entry AwesomeName) (string, string) {
+		return entry.Language.Name, entry.AwesomeName
+	}, lang, fallback)
+}
+
+// GetPokemonColor gets a Pokémon color by ID or Name.
+func (c *Client) GetPokemonColor(ctx context.Context, opts GetPokemonColorOpts) (PokemonColor, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return PokemonColor{}, err
+	}
+	return Get[PokemonColor](ctx, c, lookupValue)
+}
+
+// GetPokemonShape gets a Pokémon shape by ID or Name.
+func (c *Client) GetPokemonShape(ctx context.Context, opts GetPokemonShapeOpts) (PokemonShape, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return PokemonShape{}, err
+	}
+	return Get[PokemonShape](ctx, c, lookupValue)
+}
+
+// GetPokemonHabitat gets a Pokémon habitat by ID or Name.
+func (c *Client) GetPokemonHabitat(ctx context.Context, opts GetPokemonHabitatOpts) (PokemonHabitat, error) {
+	lookupValue, err := getLookupValue(opts.ID, opts.Name)
+	if err != nil {
+		return PokemonHabitat{}, err
+	}
+	return Get[PokemonHabitat](ctx, c, lookupValue)
+}
+
+// FilterSpecies returns the Pokémon species matching every criterion in the
+// filter, e.g. all species of color red in habitat forest.
+func (c *Client) FilterSpecies(ctx context.Context, filter SpeciesFilter) ([]NamedURL, error) {
+	var lists [][]NamedURL
+	if filter.Color != "" {
+		color, err := c.GetPokemonColor(ctx, GetPokemonColorOpts{Name: filter.Color})
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, color.PokemonSpecies)
+	}
+	if filter.Shape != "" {
+		shape, err := c.GetPokemonShape(ctx, GetPokemonShapeOpts{Name: filter.Shape})
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, shape.PokemonSpecies)
+	}
+	if filter.Habitat != "" {
+		habitat, err := c.GetPokemonHabitat(ctx, GetPokemonHabitatOpts{Name: filter.Habitat})
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, habitat.PokemonSpecies)
+	}
+	if len(lists) == 0 {
+		return nil, errors.New("you must provide at least one of Color, Shape or Habitat")
+	}
+
+	species := lists[0]
+	for _, list := range lists[1:] {
+		species = intersectByName(species, list)
+	}
+	return species, nil
+}
+
+// helper function to keep the entries of a that are also in b, in a's order
+func intersectByName(a, b []NamedURL) []NamedURL {
+	names := make(map[string]bool, len(b))
+	for _, entry := range b {
+		names[entry.Name] = true
+	}
+	result := []NamedURL{}
+	for _, entry := range a {
+		if names[entry.Name] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
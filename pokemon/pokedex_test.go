@@ -0,0 +1,133 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockPokedexServer initializes a new httptest.Server with a handler that
+// fakes the color, shape and habitat responses.
+func mockPokedexServer() *httptest.Server {
+	responses := map[string]any{
+		"/pokemon-color/red": PokemonColor{
+			ID:   8,
+			Name: "red",
+			PokemonSpecies: []NamedURL{
+				{Name: "charmander", URL: "/pokemon-species/4"},
+				{Name: "paras", URL: "/pokemon-species/46"},
+				{Name: "ledyba", URL: "/pokemon-species/165"},
+			},
+		},
+		"/pokemon-shape/wings": PokemonShape{
+			ID:   9,
+			Name: "wings",
+			AwesomeNames: []AwesomeName{
+				{AwesomeName: "Alar", Language: NamedURL{Name: "en", URL: "/language/9"}},
+			},
+			PokemonSpecies: []NamedURL{
+				{Name: "ledyba", URL: "/pokemon-species/165"},
+			},
+		},
+		"/pokemon-habitat/forest": PokemonHabitat{
+			ID:   2,
+			Name: "forest",
+			PokemonSpecies: []NamedURL{
+				{Name: "ledyba", URL: "/pokemon-species/165"},
+				{Name: "paras", URL: "/pokemon-species/46"},
+				{Name: "caterpie", URL: "/pokemon-species/10"},
+			},
+		},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestGetPokemonColorShapeHabitat(t *testing.T) {
+	server := mockPokedexServer()
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	color, err := client.GetPokemonColor(context.Background(), GetPokemonColorOpts{Name: "Red"})
+	require.NoError(t, err)
+	require.Equal(t, "red", color.Name)
+	require.Len(t, color.PokemonSpecies, 3)
+
+	shape, err := client.GetPokemonShape(context.Background(), GetPokemonShapeOpts{Name: "wings"})
+	require.NoError(t, err)
+	require.Equal(t, "Alar", shape.AwesomeNames[0].AwesomeName)
+
+	habitat, err := client.GetPokemonHabitat(context.Background(), GetPokemonHabitatOpts{Name: "forest"})
+	require.NoError(t, err)
+	require.Equal(t, 2, habitat.ID)
+
+	_, err = client.GetPokemonHabitat(context.Background(), GetPokemonHabitatOpts{Name: "unknown"})
+	require.Error(t, err)
+}
+
+func TestFilterSpecies(t *testing.T) {
+	server := mockPokedexServer()
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	tests := []struct {
+		scenario string
+		filter   SpeciesFilter
+		expected []string
+		err      bool
+	}{
+		{
+			scenario: "Color only",
+			filter:   SpeciesFilter{Color: "red"},
+			expected: []string{"charmander", "paras", "ledyba"},
+		},
+		{
+			scenario: "Color and habitat",
+			filter:   SpeciesFilter{Color: "red", Habitat: "forest"},
+			expected: []string{"paras", "ledyba"},
+		},
+		{
+			scenario: "Color, shape and habitat",
+			filter:   SpeciesFilter{Color: "red", Shape: "wings", Habitat: "forest"},
+			expected: []string{"ledyba"},
+		},
+		{
+			scenario: "Unknown habitat",
+			filter:   SpeciesFilter{Color: "red", Habitat: "unknown"},
+			err:      true,
+		},
+		{
+			scenario: "Empty filter",
+			filter:   SpeciesFilter{},
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			species, err := client.FilterSpecies(context.Background(), tt.filter)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, s := range species {
+				names = append(names, s.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
@@ -81,11 +81,13 @@ func TestAPIGetPokemon(t *testing.T) {
 		Generation: NamedURL{Name: "generation-i", URL: "/generation/i"},
 	}
 
-	pokemon, err := GetPokemon(context.Background(), GetPokemonOpts{
-		Name: "Pikachu",
+	forEachBackend(t, []string{"pokemon/pikachu"}, func(t *testing.T) {
+		pokemon, err := GetPokemon(context.Background(), GetPokemonOpts{
+			Name: "Pikachu",
+		})
+		require.NoError(t, err)
+		require.Equal(t, expectedPokemon, pokemon)
 	})
-	require.NoError(t, err)
-	require.Equal(t, expectedPokemon, pokemon)
 }
 
 // mockNatureServer initializes a new httptest.Server with a handler that
@@ -170,10 +172,12 @@ func TestAPIGetNature(t *testing.T) {
 		},
 	}
 
-	nature, err := GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
+	forEachBackend(t, []string{"nature/hardy"}, func(t *testing.T) {
+		nature, err := GetNature(context.Background(), GetNatureOpts{Name: "hardy"})
 
-	require.NoError(t, err)
-	require.Equal(t, expectedNature, nature)
+		require.NoError(t, err)
+		require.Equal(t, expectedNature, nature)
+	})
 }
 
 // mockStatServer initializes a new httptest.Server with a handler that
@@ -207,7 +211,7 @@ func mockStatServer() *httptest.Server {
 					{Name: "relaxed", URL: "/nature/relaxed"},
 				},
 			},
-			Characteristics: []Characteristic{
+			Characteristics: []APIResource{
 				{URL: "/characteristic/high-speed"},
 			},
 			MoveDamageClass: NamedURL{Name: "physical", URL: "/move-damage-class/physical"},
@@ -270,7 +274,7 @@ func TestAPIGetStat(t *testing.T) {
 				},
 			},
 		},
-		Characteristics: []Characteristic{
+		Characteristics: []APIResource{
 			{
 				URL: "/characteristic/high-speed",
 			},
@@ -290,8 +294,10 @@ func TestAPIGetStat(t *testing.T) {
 		},
 	}
 
-	stat, err := GetStat(context.Background(), GetStatOpts{Name: "speed"})
+	forEachBackend(t, []string{"stat/speed"}, func(t *testing.T) {
+		stat, err := GetStat(context.Background(), GetStatOpts{Name: "speed"})
 
-	require.NoError(t, err)
-	require.Equal(t, expectedStat, stat)
+		require.NoError(t, err)
+		require.Equal(t, expectedStat, stat)
+	})
 }
@@ -0,0 +1,123 @@
+// Package pokemonotel records traces and metrics for a pokemon.Client with
+// OpenTelemetry.
+//
+//	hooks, err := pokemonotel.NewHooks(nil, nil)
+//	if err != nil {
+//		return err
+//	}
+//	client.Hooks = hooks
+package pokemonotel
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ashgodfrey/pokemon-api/pokemon"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/ashgodfrey/pokemon-api/pokemon"
+
+// Hooks implements pokemon.Hooks, starting a span for every SDK call with a
+// child span for each HTTP request it makes, and recording these metrics:
+//
+//   - pokemon.client.requests: requests by resource and status code
+//   - pokemon.client.request.duration: request latency in seconds
+//   - pokemon.client.cache.lookups: cache lookups by resource and hit, for the hit ratio
+type Hooks struct {
+	tracer       trace.Tracer
+	requests     metric.Int64Counter
+	duration     metric.Float64Histogram
+	cacheLookups metric.Int64Counter
+}
+
+var _ pokemon.Hooks = (*Hooks)(nil)
+
+// NewHooks creates Hooks that record to the given providers. Nil providers
+// use the global ones registered with otel.
+func NewHooks(tp trace.TracerProvider, mp metric.MeterProvider) (*Hooks, error) {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(ScopeName)
+	h := &Hooks{tracer: tp.Tracer(ScopeName)}
+
+	var err error
+	if h.requests, err = meter.Int64Counter("pokemon.client.requests",
+		metric.WithDescription("HTTP requests sent to the API."),
+		metric.WithUnit("{request}")); err != nil {
+		return nil, err
+	}
+	if h.duration, err = meter.Float64Histogram("pokemon.client.request.duration",
+		metric.WithDescription("Latency of HTTP requests sent to the API."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if h.cacheLookups, err = meter.Int64Counter("pokemon.client.cache.lookups",
+		metric.WithDescription("Cache lookups, labelled by whether a fresh entry was served."),
+		metric.WithUnit("{lookup}")); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// StartCall starts a span named after the call.
+func (h *Hooks) StartCall(ctx context.Context, name string) (context.Context, func(error)) {
+	ctx, span := h.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// StartRequest starts a client span for the request and records its metrics when it ends.
+func (h *Hooks) StartRequest(ctx context.Context, info pokemon.RequestInfo) (context.Context, func(pokemon.RequestResult)) {
+	ctx, span := h.tracer.Start(ctx, info.Method+" "+info.Resource,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", info.Method),
+			attribute.String("url.full", info.URL),
+			attribute.String("pokemon.resource", info.Resource),
+		))
+	return ctx, func(result pokemon.RequestResult) {
+		attrs := []attribute.KeyValue{attribute.String("pokemon.resource", info.Resource)}
+		switch {
+		case result.Err != nil:
+			span.RecordError(result.Err)
+			span.SetStatus(codes.Error, result.Err.Error())
+			attrs = append(attrs, attribute.String("error.type", "network"))
+		default:
+			span.SetAttributes(attribute.Int("http.response.status_code", result.StatusCode))
+			if result.StatusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, http.StatusText(result.StatusCode))
+			}
+			attrs = append(attrs, attribute.Int("http.response.status_code", result.StatusCode))
+		}
+		span.End()
+
+		set := metric.WithAttributes(attrs...)
+		h.requests.Add(ctx, 1, set)
+		h.duration.Record(ctx, result.Duration.Seconds(), set)
+	}
+}
+
+// CacheLookup counts the lookup and adds it as an event to the current span.
+func (h *Hooks) CacheLookup(ctx context.Context, resource string, hit bool) {
+	attrs := []attribute.KeyValue{
+		attribute.String("pokemon.resource", resource),
+		attribute.Bool("pokemon.cache.hit", hit),
+	}
+	h.cacheLookups.Add(ctx, 1, metric.WithAttributes(attrs...))
+	trace.SpanFromContext(ctx).AddEvent("cache lookup", trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,120 @@
+package pokemonotel_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ashgodfrey/pokemon-api/pokemon"
+	"github.com/ashgodfrey/pokemon-api/pokemon/pokemonotel"
+	"github.com/ashgodfrey/pokemon-api/pokemon/pokemontest"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setup(t *testing.T) (*pokemontest.Server, *pokemon.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	server := pokemontest.NewServer()
+	t.Cleanup(server.Close)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	hooks, err := pokemonotel.NewHooks(
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+	require.NoError(t, err)
+
+	client := server.Client()
+	client.Hooks = hooks
+	return server, client, spans, reader
+}
+
+// helper function to sum a counter's data points matching every attribute in want
+func counterValue(t *testing.T, reader *sdkmetric.ManualReader, name string, want ...attribute.KeyValue) int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var total int64
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok, "%s is not an int64 counter", name)
+		points:
+			for _, point := range sum.DataPoints {
+				for _, attr := range want {
+					if value, ok := point.Attributes.Value(attr.Key); !ok || value != attr.Value {
+						continue points
+					}
+				}
+				total += point.Value
+			}
+		}
+	}
+	return total
+}
+
+func TestGetPokemonSpans(t *testing.T) {
+	_, client, spans, reader := setup(t)
+
+	_, err := client.GetPokemon(context.Background(), pokemon.GetPokemonOpts{Name: "pikachu", IncludeLocation: true})
+	require.NoError(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 3)
+	call := ended[2]
+	require.Equal(t, "pokemon.GetPokemon", call.Name())
+	require.False(t, call.Parent().IsValid())
+
+	require.Equal(t, "GET pokemon", ended[0].Name())
+	require.Equal(t, "GET pokemon/encounters", ended[1].Name())
+	for _, span := range ended[:2] {
+		require.Equal(t, call.SpanContext().SpanID(), span.Parent().SpanID())
+		require.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+	}
+
+	require.EqualValues(t, 1, counterValue(t, reader, "pokemon.client.requests", attribute.String("pokemon.resource", "pokemon")))
+	require.EqualValues(t, 1, counterValue(t, reader, "pokemon.client.requests", attribute.String("pokemon.resource", "pokemon/encounters")))
+}
+
+func TestErrors(t *testing.T) {
+	server, client, spans, reader := setup(t)
+	server.InjectFault(pokemontest.Fault{Path: "nature/", Status: http.StatusInternalServerError, Times: 1})
+
+	_, err := client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "hardy"})
+	require.Error(t, err)
+	_, err = client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "hardy"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, counterValue(t, reader, "pokemon.client.requests", attribute.Int("http.response.status_code", http.StatusInternalServerError)))
+	require.EqualValues(t, 1, counterValue(t, reader, "pokemon.client.requests", attribute.Int("http.response.status_code", http.StatusOK)))
+
+	_, err = client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "unknown"})
+	require.ErrorIs(t, err, pokemon.ErrNotFound)
+	ended := spans.Ended()
+	call := ended[len(ended)-1]
+	require.Equal(t, "pokemon.GetNature", call.Name())
+	require.Equal(t, codes.Error, call.Status().Code)
+}
+
+func TestCacheLookups(t *testing.T) {
+	server, client, _, reader := setup(t)
+	client.Cache = pokemon.NewMemoryCache()
+	client.CacheTTL = time.Minute
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetStat(context.Background(), pokemon.GetStatOpts{Name: "speed"})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, server.RequestCount("stat/speed"))
+	require.EqualValues(t, 2, counterValue(t, reader, "pokemon.client.cache.lookups", attribute.Bool("pokemon.cache.hit", true)))
+	require.EqualValues(t, 1, counterValue(t, reader, "pokemon.client.cache.lookups", attribute.Bool("pokemon.cache.hit", false)))
+}
@@ -0,0 +1,91 @@
+// Package pokemonprom exports Prometheus metrics for a pokemon.Client.
+//
+//	hooks, err := pokemonprom.NewHooks(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		return err
+//	}
+//	client.Hooks = hooks
+package pokemonprom
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ashgodfrey/pokemon-api/pokemon"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Hooks implements pokemon.Hooks, recording these metrics:
+//
+//   - pokemon_requests_total: requests by resource and status
+//   - pokemon_request_duration_seconds: request latency by resource and status
+//   - pokemon_cache_hits_total: fresh cache entries served, by resource
+//   - pokemon_requests_in_flight: requests awaiting a response, by resource
+//
+// Status is the HTTP status code, or "error" when the request failed
+// without a response.
+type Hooks struct {
+	pokemon.NopHooks
+
+	requests  *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	cacheHits *prometheus.CounterVec
+	inFlight  *prometheus.GaugeVec
+}
+
+var _ pokemon.Hooks = (*Hooks)(nil)
+
+// NewHooks creates Hooks and registers their metrics with reg. A nil reg
+// uses prometheus.DefaultRegisterer.
+func NewHooks(reg prometheus.Registerer) (*Hooks, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	h := &Hooks{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pokemon_requests_total",
+			Help: "HTTP requests sent to the API.",
+		}, []string{"resource", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pokemon_request_duration_seconds",
+			Help:    "Latency of HTTP requests sent to the API.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"resource", "status"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pokemon_cache_hits_total",
+			Help: "Responses served from the cache without a request.",
+		}, []string{"resource"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "pokemon_requests_in_flight",
+			Help: "HTTP requests awaiting a response.",
+		}, []string{"resource"}),
+	}
+	for _, collector := range []prometheus.Collector{h.requests, h.duration, h.cacheHits, h.inFlight} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// StartRequest tracks the request as in flight and records it once it ends.
+func (h *Hooks) StartRequest(ctx context.Context, info pokemon.RequestInfo) (context.Context, func(pokemon.RequestResult)) {
+	inFlight := h.inFlight.WithLabelValues(info.Resource)
+	inFlight.Inc()
+	return ctx, func(result pokemon.RequestResult) {
+		inFlight.Dec()
+		status := "error"
+		if result.Err == nil {
+			status = strconv.Itoa(result.StatusCode)
+		}
+		h.requests.WithLabelValues(info.Resource, status).Inc()
+		h.duration.WithLabelValues(info.Resource, status).Observe(result.Duration.Seconds())
+	}
+}
+
+// CacheLookup counts cache hits.
+func (h *Hooks) CacheLookup(ctx context.Context, resource string, hit bool) {
+	if hit {
+		h.cacheHits.WithLabelValues(resource).Inc()
+	}
+}
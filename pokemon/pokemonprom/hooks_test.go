@@ -0,0 +1,118 @@
+package pokemonprom_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ashgodfrey/pokemon-api/pokemon"
+	"github.com/ashgodfrey/pokemon-api/pokemon/pokemonprom"
+	"github.com/ashgodfrey/pokemon-api/pokemon/pokemontest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) (*pokemontest.Server, *pokemon.Client, *prometheus.Registry) {
+	t.Helper()
+	server := pokemontest.NewServer()
+	t.Cleanup(server.Close)
+
+	reg := prometheus.NewRegistry()
+	hooks, err := pokemonprom.NewHooks(reg)
+	require.NoError(t, err)
+
+	client := server.Client()
+	client.Hooks = hooks
+	return server, client, reg
+}
+
+// helper function to get the value of the gauge or counter with the given labels
+func value(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if want, ok := labels[label.GetName()]; ok && want != label.GetValue() {
+					continue metrics
+				}
+			}
+			if m.GetGauge() != nil {
+				return m.GetGauge().GetValue()
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestRequestMetrics(t *testing.T) {
+	server, client, reg := setup(t)
+	server.InjectFault(pokemontest.Fault{Path: "pokemon/pikachu/encounters", Status: http.StatusInternalServerError, Times: 1})
+
+	_, err := client.GetPokemon(context.Background(), pokemon.GetPokemonOpts{Name: "pikachu", IncludeLocation: true})
+	require.Error(t, err)
+	_, err = client.GetPokemon(context.Background(), pokemon.GetPokemonOpts{Name: "pikachu", IncludeLocation: true})
+	require.NoError(t, err)
+	_, err = client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "unknown"})
+	require.ErrorIs(t, err, pokemon.ErrNotFound)
+
+	expected := `
+# HELP pokemon_requests_total HTTP requests sent to the API.
+# TYPE pokemon_requests_total counter
+pokemon_requests_total{resource="nature",status="404"} 1
+pokemon_requests_total{resource="pokemon",status="200"} 2
+pokemon_requests_total{resource="pokemon/encounters",status="200"} 1
+pokemon_requests_total{resource="pokemon/encounters",status="500"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "pokemon_requests_total"))
+	count, err := testutil.GatherAndCount(reg, "pokemon_request_duration_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+}
+
+func TestCacheHits(t *testing.T) {
+	_, client, reg := setup(t)
+	client.Cache = pokemon.NewMemoryCache()
+	client.CacheTTL = time.Minute
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2.0, value(t, reg, "pokemon_cache_hits_total", map[string]string{"resource": "nature"}))
+}
+
+func TestInFlight(t *testing.T) {
+	server, client, reg := setup(t)
+	server.InjectFault(pokemontest.Fault{Path: "stat/", Latency: 200 * time.Millisecond})
+
+	done := make(chan error)
+	go func() {
+		_, err := client.GetStat(context.Background(), pokemon.GetStatOpts{Name: "speed"})
+		done <- err
+	}()
+
+	inFlight := map[string]string{"resource": "stat"}
+	require.Eventually(t, func() bool {
+		return value(t, reg, "pokemon_requests_in_flight", inFlight) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, <-done)
+	require.Equal(t, 0.0, value(t, reg, "pokemon_requests_in_flight", inFlight))
+}
+
+func TestNewHooksRegistersOnce(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	_, err := pokemonprom.NewHooks(reg)
+	require.NoError(t, err)
+	_, err = pokemonprom.NewHooks(reg)
+	require.Error(t, err)
+}
@@ -0,0 +1,395 @@
+// Package pokemontest provides a fake PokeAPI server for testing code that
+// uses the pokemon package.
+package pokemontest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ashgodfrey/pokemon-api/pokemon"
+)
+
+// APIPath is the path the fake API is served under, as on pokeapi.co.
+const APIPath = "/api/v2/"
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Fault describes a failure the server injects into matching requests.
+type Fault struct {
+	// Path limits the fault to requests for the path below APIPath or
+	// anything beneath it, matched by whole segments: "pokemon" matches
+	// "pokemon" and "pokemon/25" but not "pokemon-species". Empty matches
+	// every request.
+	Path string
+	// Latency delays the response, or the failure, by the given duration.
+	Latency time.Duration
+	// Status fails the request with the given status code, such as 429 or 500.
+	Status int
+	// MalformedJSON truncates the response body so it fails to decode.
+	MalformedJSON bool
+	// Times limits the fault to the first n matching requests. Zero means every request.
+	Times int
+}
+
+type resource struct {
+	id   int
+	name string
+	body []byte
+	subs map[string][]byte
+}
+
+// Server is a fake PokeAPI that serves fixture JSON with the API's path
+// semantics: lookups by ID or name, paginated list endpoints, sub-resources
+// such as pokemon/{id}/encounters, and 404s for anything unknown.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	resources map[string][]*resource
+	faults    []*Fault
+	requests  []string
+}
+
+// NewServer starts a Server loaded with the bundled fixtures. Callers should
+// call Close when finished.
+func NewServer() *Server {
+	s := NewEmptyServer()
+	// Sub-resources are added once every resource they belong to is loaded.
+	var subs []string
+	err := fs.WalkDir(fixtures, "fixtures", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if strings.Count(name, "/") == 3 {
+			subs = append(subs, name)
+			return nil
+		}
+		data, err := fixtures.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		return s.AddJSON(path.Base(path.Dir(name)), data)
+	})
+	for _, name := range subs {
+		if err != nil {
+			break
+		}
+		var data []byte
+		if data, err = fixtures.ReadFile(name); err == nil {
+			parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(name, "fixtures/"), ".json"), "/")
+			err = s.AddSubResource(parts[0], parts[1], parts[2], data)
+		}
+	}
+	if err != nil {
+		panic(fmt.Sprintf("pokemontest: loading fixtures: %v", err))
+	}
+	return s
+}
+
+// NewEmptyServer starts a Server with no resources.
+func NewEmptyServer() *Server {
+	s := &Server{resources: make(map[string][]*resource)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Endpoint returns the URL to use as pokemon.Client.Endpoint.
+func (s *Server) Endpoint() string {
+	return s.URL + APIPath
+}
+
+// Client returns a pokemon.Client configured to use the server.
+func (s *Server) Client() *pokemon.Client {
+	return &pokemon.Client{
+		HTTPClient: s.Server.Client(),
+		Endpoint:   s.Endpoint(),
+	}
+}
+
+// Add adds a resource of the given kind, such as "pokemon", encoded as JSON.
+// The resource must have an "id" and may have a "name".
+func (s *Server) Add(kind string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.AddJSON(kind, data)
+}
+
+// AddJSON adds a resource of the given kind from its raw JSON, replacing any
+// resource with the same ID.
+func (s *Server) AddJSON(kind string, data []byte) error {
+	var ref struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return fmt.Errorf("pokemontest: decoding %s: %w", kind, err)
+	}
+	if ref.ID == 0 {
+		return fmt.Errorf("pokemontest: %s has no id", kind)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := s.resources[kind]
+	for i, existing := range list {
+		if existing.id == ref.ID {
+			list[i] = &resource{id: ref.ID, name: ref.Name, body: data, subs: existing.subs}
+			return nil
+		}
+	}
+	list = append(list, &resource{id: ref.ID, name: ref.Name, body: data, subs: make(map[string][]byte)})
+	sort.Slice(list, func(i, j int) bool { return list[i].id < list[j].id })
+	s.resources[kind] = list
+	return nil
+}
+
+// AddSubResource adds a sub-resource, such as the encounters of a Pokémon,
+// to an existing resource identified by ID or name.
+func (s *Server) AddSubResource(kind, idOrName, sub string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.lookup(kind, idOrName)
+	if r == nil {
+		return fmt.Errorf("pokemontest: no %s %q for sub-resource %s", kind, idOrName, sub)
+	}
+	r.subs[sub] = data
+	return nil
+}
+
+// Remove removes a resource, so later requests for it return 404.
+func (s *Server) Remove(kind, idOrName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := s.resources[kind]
+	for i, r := range list {
+		if strconv.Itoa(r.id) == idOrName || r.name == idOrName {
+			s.resources[kind] = append(list[:i], list[i+1:]...)
+			return
+		}
+	}
+}
+
+// InjectFault adds a fault. Faults are checked in the order they were added
+// and the first matching one applies.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the path and query of every request received, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// RequestCount returns the number of requests received for the given path
+// below APIPath, ignoring trailing slashes and the query.
+func (s *Server) RequestCount(p string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	want := strings.Trim(p, "/")
+	count := 0
+	for _, request := range s.requests {
+		request = strings.SplitN(request, "?", 2)[0]
+		if strings.Trim(strings.TrimPrefix(request, APIPath), "/") == want {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL.RequestURI())
+	s.mu.Unlock()
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, APIPath) {
+		http.NotFound(w, r)
+		return
+	}
+	p := strings.Trim(strings.TrimPrefix(path.Clean(r.URL.Path), strings.TrimSuffix(APIPath, "/")), "/")
+
+	fault := s.matchFault(p)
+	if fault != nil && fault.Latency > 0 {
+		select {
+		case <-time.After(fault.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if fault != nil && fault.Status != 0 {
+		if fault.Status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "1")
+		}
+		http.Error(w, http.StatusText(fault.Status), fault.Status)
+		return
+	}
+
+	body, status := s.route(p, r)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	if fault != nil && fault.MalformedJSON {
+		body = body[:len(body)/2]
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// helper function to find the first fault matching p, consuming one of its uses
+func (s *Server) matchFault(p string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.faults {
+		if !matchPath(p, strings.Trim(f.Path, "/")) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// helper function to check p is prefix or lies beneath it
+func matchPath(p, prefix string) bool {
+	return prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// helper function to serve the resource, sub-resource or list page at p
+func (s *Server) route(p string, r *http.Request) ([]byte, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p == "" {
+		return s.index(r)
+	}
+	parts := strings.Split(p, "/")
+	switch len(parts) {
+	case 1:
+		if _, ok := s.resources[parts[0]]; !ok {
+			return nil, http.StatusNotFound
+		}
+		return s.list(parts[0], r)
+	case 2:
+		if res := s.lookup(parts[0], parts[1]); res != nil {
+			return res.body, http.StatusOK
+		}
+	case 3:
+		if res := s.lookup(parts[0], parts[1]); res != nil {
+			if body, ok := res.subs[parts[2]]; ok {
+				return body, http.StatusOK
+			}
+		}
+	}
+	return nil, http.StatusNotFound
+}
+
+// helper function to serve the root of the API, mapping each kind to its list endpoint; s.mu must be held
+func (s *Server) index(r *http.Request) ([]byte, int) {
+	index := make(map[string]string, len(s.resources))
+	for kind := range s.resources {
+		index[kind] = "http://" + r.Host + APIPath + kind + "/"
+	}
+	body, err := json.Marshal(index)
+	if err != nil {
+		return nil, http.StatusInternalServerError
+	}
+	return body, http.StatusOK
+}
+
+// helper function to find a resource by ID or name; s.mu must be held
+func (s *Server) lookup(kind, idOrName string) *resource {
+	for _, r := range s.resources[kind] {
+		if strconv.Itoa(r.id) == idOrName || (r.name != "" && r.name == idOrName) {
+			return r
+		}
+	}
+	return nil
+}
+
+// helper function to serve a list page like the API, defaulting to 20 results; s.mu must be held
+func (s *Server) list(kind string, r *http.Request) ([]byte, int) {
+	all := s.resources[kind]
+	limit, offset := 20, 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, http.StatusBadRequest
+		}
+		limit = n
+	}
+	if value := r.URL.Query().Get("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, http.StatusBadRequest
+		}
+		offset = n
+	}
+	start, end := offset, offset+limit
+	if start > len(all) {
+		start = len(all)
+	}
+	if end > len(all) {
+		end = len(all)
+	}
+
+	base := "http://" + r.Host + APIPath + kind + "/"
+	page := struct {
+		Count    int                `json:"count"`
+		Next     *string            `json:"next"`
+		Previous *string            `json:"previous"`
+		Results  []pokemon.NamedURL `json:"results"`
+	}{Count: len(all), Results: []pokemon.NamedURL{}}
+	for _, res := range all[start:end] {
+		page.Results = append(page.Results, pokemon.NamedURL{Name: res.name, URL: base + strconv.Itoa(res.id) + "/"})
+	}
+	if end < len(all) {
+		next := fmt.Sprintf("%s?offset=%d&limit=%d", base, end, limit)
+		page.Next = &next
+	}
+	if start > 0 {
+		previousOffset := start - limit
+		if previousOffset < 0 {
+			previousOffset = 0
+		}
+		previous := fmt.Sprintf("%s?offset=%d&limit=%d", base, previousOffset, limit)
+		page.Previous = &previous
+	}
+	body, err := json.Marshal(page)
+	if err != nil {
+		return nil, http.StatusInternalServerError
+	}
+	return body, http.StatusOK
+}
@@ -0,0 +1,154 @@
+package pokemontest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ashgodfrey/pokemon-api/pokemon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerLookups(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	t.Run("By name", func(t *testing.T) {
+		p, err := client.GetPokemon(context.Background(), pokemon.GetPokemonOpts{Name: "Pikachu"})
+		require.NoError(t, err)
+		require.Equal(t, 25, p.ID)
+	})
+
+	t.Run("By ID", func(t *testing.T) {
+		nature, err := client.GetNature(context.Background(), pokemon.GetNatureOpts{ID: 2})
+		require.NoError(t, err)
+		require.Equal(t, "bold", nature.Name)
+		require.Equal(t, "Assuré", nature.Names.NameIn("fr"))
+	})
+
+	t.Run("Sub-resource", func(t *testing.T) {
+		p, err := client.GetPokemon(context.Background(), pokemon.GetPokemonOpts{ID: 25, IncludeLocation: true})
+		require.NoError(t, err)
+		require.Contains(t, string(p.LocationAreaEncounters), "viridian-forest-area")
+		require.Equal(t, 1, server.RequestCount("pokemon/25/encounters"))
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := client.GetPokemon(context.Background(), pokemon.GetPokemonOpts{Name: "missingno"})
+		require.ErrorIs(t, err, pokemon.ErrNotFound)
+
+		_, err = client.GetPokemon(context.Background(), pokemon.GetPokemonOpts{ID: 1, IncludeLocation: true})
+		require.ErrorIs(t, err, pokemon.ErrNotFound)
+
+		_, err = client.ListResources(context.Background(), "berry", pokemon.ListOpts{})
+		require.ErrorIs(t, err, pokemon.ErrNotFound)
+	})
+
+	t.Run("Added and removed resources", func(t *testing.T) {
+		require.NoError(t, server.Add("nature", pokemon.Nature{ID: 10, Name: "calm"}))
+		nature, err := client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "calm"})
+		require.NoError(t, err)
+		require.Equal(t, 10, nature.ID)
+
+		server.Remove("nature", "calm")
+		_, err = client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "calm"})
+		require.ErrorIs(t, err, pokemon.ErrNotFound)
+
+		require.Error(t, server.Add("nature", pokemon.Nature{Name: "no-id"}))
+	})
+}
+
+func TestServerPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	page, err := client.ListResources(context.Background(), "pokemon", pokemon.ListOpts{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, page.Count)
+	require.Equal(t, []string{"bulbasaur", "charmander"}, names(page.Results))
+	require.Equal(t, server.Endpoint()+"pokemon/?offset=2&limit=2", page.Next)
+	require.Empty(t, page.Previous)
+	require.Equal(t, server.Endpoint()+"pokemon/1/", page.Results[0].URL)
+
+	page, err = client.ListResources(context.Background(), "pokemon", pokemon.ListOpts{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pikachu"}, names(page.Results))
+	require.Empty(t, page.Next)
+	require.Equal(t, server.Endpoint()+"pokemon/?offset=0&limit=2", page.Previous)
+
+	page, err = client.ListResources(context.Background(), "pokemon", pokemon.ListOpts{Offset: 10})
+	require.NoError(t, err)
+	require.Empty(t, page.Results)
+}
+
+func TestServerFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	t.Run("Status", func(t *testing.T) {
+		server.InjectFault(Fault{Path: "nature/", Status: http.StatusTooManyRequests, Times: 1})
+
+		_, err := client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "hardy"})
+		require.ErrorContains(t, err, "429")
+
+		_, err = client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Server error only on matching paths", func(t *testing.T) {
+		server.InjectFault(Fault{Path: "stat", Status: http.StatusInternalServerError})
+		defer server.ClearFaults()
+
+		_, err := client.GetStat(context.Background(), pokemon.GetStatOpts{Name: "speed"})
+		require.ErrorContains(t, err, "500")
+
+		_, err = client.GetNature(context.Background(), pokemon.GetNatureOpts{Name: "hardy"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Paths match whole segments", func(t *testing.T) {
+		require.NoError(t, server.Add("pokemon-species", map[string]any{"id": 25, "name": "pikachu"}))
+		server.InjectFault(Fault{Path: "pokemon", Status: http.StatusInternalServerError})
+		defer server.ClearFaults()
+
+		_, err := client.GetPokemon(context.Background(), pokemon.GetPokemonOpts{Name: "pikachu"})
+		require.ErrorContains(t, err, "500")
+		_, err = client.ListResources(context.Background(), "pokemon", pokemon.ListOpts{})
+		require.ErrorContains(t, err, "500")
+
+		species, err := client.ListResources(context.Background(), "pokemon-species", pokemon.ListOpts{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"pikachu"}, names(species.Results))
+		_, err = client.GetPokemonColor(context.Background(), pokemon.GetPokemonColorOpts{ID: 8})
+		require.NoError(t, err)
+	})
+
+	t.Run("Malformed JSON", func(t *testing.T) {
+		server.InjectFault(Fault{MalformedJSON: true, Times: 1})
+
+		_, err := client.GetStat(context.Background(), pokemon.GetStatOpts{Name: "speed"})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, pokemon.ErrNotFound)
+	})
+
+	t.Run("Latency", func(t *testing.T) {
+		server.InjectFault(Fault{Latency: time.Second, Times: 1})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.GetStat(ctx, pokemon.GetStatOpts{Name: "speed"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func names(results []pokemon.NamedURL) []string {
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Name)
+	}
+	return names
+}
@@ -0,0 +1,132 @@
+package pokemon
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// PublicEndpoint is the base URL of the public PokeAPI.
+const PublicEndpoint = "https://pokeapi.co/api/v2/"
+
+// Built-in endpoint profiles.
+const (
+	// ProfilePublic is the public API at PublicEndpoint.
+	ProfilePublic = "public"
+	// ProfileMirror is a self-hosted deployment whose base URL is read from
+	// EnvMirrorURL unless registered with RegisterProfile.
+	ProfileMirror = "mirror"
+	// ProfileLocal is a deployment running locally, e.g. from the PokeAPI
+	// repository's docker-compose setup.
+	ProfileLocal = "local"
+)
+
+const (
+	// EnvBaseURL names the environment variable selecting the endpoint of
+	// NewClient and DefaultClient. It holds a profile name or a base URL.
+	EnvBaseURL = "POKEAPI_BASE_URL"
+	// EnvMirrorURL names the environment variable holding the base URL of
+	// the mirror profile.
+	EnvMirrorURL = "POKEAPI_MIRROR_URL"
+)
+
+var profiles = struct {
+	sync.RWMutex
+	endpoints map[string]string
+}{endpoints: map[string]string{
+	ProfilePublic: PublicEndpoint,
+	ProfileLocal:  "http://localhost:8000/api/v2/",
+}}
+
+// RegisterProfile adds or replaces a named endpoint profile.
+func RegisterProfile(name, endpoint string) {
+	profiles.Lock()
+	defer profiles.Unlock()
+	profiles.endpoints[name] = withTrailingSlash(endpoint)
+}
+
+// ProfileEndpoint returns the base URL of a named profile.
+func ProfileEndpoint(name string) (string, bool) {
+	profiles.RLock()
+	endpoint, ok := profiles.endpoints[name]
+	profiles.RUnlock()
+	if !ok && name == ProfileMirror {
+		if mirror := os.Getenv(EnvMirrorURL); mirror != "" {
+			return withTrailingSlash(mirror), true
+		}
+	}
+	return endpoint, ok
+}
+
+// NewClientForProfile creates a client for a named profile. Clients for
+// profiles other than the public API rewrite references to the public API
+// in responses to their own endpoint.
+func NewClientForProfile(name string) (*Client, error) {
+	endpoint, ok := ProfileEndpoint(name)
+	if !ok && name == ProfileMirror {
+		return nil, fmt.Errorf("endpoint profile %q needs %s to be set", name, EnvMirrorURL)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown endpoint profile %q", name)
+	}
+	return newClientForEndpoint(endpoint), nil
+}
+
+// helper function to create a client for endpoint, rewriting references to
+// the public API when it is a different server
+func newClientForEndpoint(endpoint string) *Client {
+	c := &Client{HTTPClient: http.DefaultClient, Endpoint: endpoint}
+	if endpoint != PublicEndpoint {
+		c.ReferenceBase = PublicEndpoint
+	}
+	return c
+}
+
+// helper function to get the endpoint selected by EnvBaseURL, a profile name
+// or a base URL, defaulting to the public API. A value that is neither, such
+// as the mirror profile without EnvMirrorURL, is returned unchanged with an
+// error so the client fails closed instead of using another server.
+func endpointFromEnv() (string, error) {
+	value := os.Getenv(EnvBaseURL)
+	if value == "" {
+		return PublicEndpoint, nil
+	}
+	if endpoint, ok := ProfileEndpoint(value); ok {
+		return endpoint, nil
+	}
+	if u, err := url.Parse(value); err == nil && u.IsAbs() && u.Host != "" {
+		return withTrailingSlash(value), nil
+	}
+	if value == ProfileMirror {
+		return value, fmt.Errorf("invalid %s %q: %s is not set", EnvBaseURL, value, EnvMirrorURL)
+	}
+	return value, fmt.Errorf("invalid %s %q: not a profile or an absolute URL", EnvBaseURL, value)
+}
+
+// helper function to rewrite resource references in body from the client's
+// ReferenceBase to its Endpoint, so followed references stay on the same server
+func rewriteReferences(c *Client, body []byte) []byte {
+	if !c.rewritesReferences() {
+		return body
+	}
+	from := withTrailingSlash(c.ReferenceBase)
+	to := withTrailingSlash(c.Endpoint)
+	if !bytes.Contains(body, []byte(from)) {
+		return body
+	}
+	return bytes.ReplaceAll(body, []byte(`"`+from), []byte(`"`+to))
+}
+
+// helper function to check whether the client rewrites references, which is
+// when its ReferenceBase is set and differs from its Endpoint
+func (c *Client) rewritesReferences() bool {
+	return c.ReferenceBase != "" && withTrailingSlash(c.ReferenceBase) != withTrailingSlash(c.Endpoint)
+}
+
+func withTrailingSlash(endpoint string) string {
+	return strings.TrimSuffix(endpoint, "/") + "/"
+}
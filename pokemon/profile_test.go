@@ -0,0 +1,137 @@
+package pokemon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// registerProfileForTest registers a profile for the rest of the test,
+// removing it afterwards so it doesn't leak into other tests.
+func registerProfileForTest(t *testing.T, name, endpoint string) {
+	t.Helper()
+	profiles.RLock()
+	previous, existed := profiles.endpoints[name]
+	profiles.RUnlock()
+	t.Cleanup(func() {
+		profiles.Lock()
+		defer profiles.Unlock()
+		if existed {
+			profiles.endpoints[name] = previous
+		} else {
+			delete(profiles.endpoints, name)
+		}
+	})
+	RegisterProfile(name, endpoint)
+}
+
+func TestProfiles(t *testing.T) {
+	t.Run("Built-in profiles", func(t *testing.T) {
+		endpoint, ok := ProfileEndpoint(ProfilePublic)
+		require.True(t, ok)
+		require.Equal(t, PublicEndpoint, endpoint)
+
+		client, err := NewClientForProfile(ProfileLocal)
+		require.NoError(t, err)
+		require.Equal(t, "http://localhost:8000/api/v2/", client.Endpoint)
+		require.Equal(t, PublicEndpoint, client.ReferenceBase)
+
+		_, err = NewClientForProfile("staging")
+		require.ErrorContains(t, err, `unknown endpoint profile "staging"`)
+	})
+
+	t.Run("Mirror from the environment", func(t *testing.T) {
+		t.Setenv(EnvMirrorURL, "https://pokeapi.internal/api/v2")
+		client, err := NewClientForProfile(ProfileMirror)
+		require.NoError(t, err)
+		require.Equal(t, "https://pokeapi.internal/api/v2/", client.Endpoint)
+	})
+
+	t.Run("Mirror without its URL", func(t *testing.T) {
+		t.Setenv(EnvMirrorURL, "")
+		_, err := NewClientForProfile(ProfileMirror)
+		require.ErrorContains(t, err, EnvMirrorURL)
+	})
+
+	t.Run("Registered profile", func(t *testing.T) {
+		registerProfileForTest(t, "test-registered", "https://mirror.example/api/v2")
+		endpoint, ok := ProfileEndpoint("test-registered")
+		require.True(t, ok)
+		require.Equal(t, "https://mirror.example/api/v2/", endpoint)
+	})
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	tests := []struct {
+		scenario      string
+		env           string
+		endpoint      string
+		referenceBase string
+		configErr     string
+	}{
+		{scenario: "Unset uses the public API", env: "", endpoint: PublicEndpoint},
+		{scenario: "Profile name", env: ProfileLocal, endpoint: "http://localhost:8000/api/v2/", referenceBase: PublicEndpoint},
+		{scenario: "Base URL", env: "https://pokeapi.internal/api/v2", endpoint: "https://pokeapi.internal/api/v2/", referenceBase: PublicEndpoint},
+		{scenario: "Mirror without its URL", env: ProfileMirror, endpoint: ProfileMirror, referenceBase: PublicEndpoint, configErr: EnvMirrorURL + " is not set"},
+		{scenario: "Unknown profile", env: "staging", endpoint: "staging", referenceBase: PublicEndpoint, configErr: "not a profile or an absolute URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Setenv(EnvBaseURL, tt.env)
+			t.Setenv(EnvMirrorURL, "")
+
+			client := NewClient()
+			require.Equal(t, tt.endpoint, client.Endpoint)
+			require.Equal(t, tt.referenceBase, client.ReferenceBase)
+			if tt.configErr == "" {
+				require.NoError(t, client.configErr)
+				return
+			}
+			_, err := client.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+			require.ErrorContains(t, err, EnvBaseURL)
+			require.ErrorContains(t, err, tt.configErr)
+		})
+	}
+}
+
+func TestReferenceRewriting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/pokemon/pikachu":
+			w.Write([]byte(`{"id": 25, "name": "pikachu",
+				"species": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"},
+				"location_area_encounters": "https://pokeapi.co/api/v2/pokemon/25/encounters"}`))
+		case "/api/v2/pokemon/":
+			w.Write([]byte(`{"count": 1, "results": [{"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon/25/"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	registerProfileForTest(t, "test-mirror", server.URL+"/api/v2")
+	client, err := NewClientForProfile("test-mirror")
+	require.NoError(t, err)
+	client.HTTPClient = server.Client()
+
+	pokemon, err := client.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/api/v2/pokemon-species/25/", pokemon.Species.URL)
+	require.Equal(t, EncountersData(server.URL+"/api/v2/pokemon/25/encounters"), pokemon.LocationAreaEncounters)
+
+	list, err := client.ListResources(context.Background(), "pokemon", ListOpts{})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(list.Results[0].URL, server.URL+"/api/v2/"))
+
+	t.Run("Disabled without ReferenceBase", func(t *testing.T) {
+		client := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/api/v2/"}
+		pokemon, err := client.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+		require.NoError(t, err)
+		require.Equal(t, "https://pokeapi.co/api/v2/pokemon-species/25/", pokemon.Species.URL)
+	})
+}
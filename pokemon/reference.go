@@ -0,0 +1,91 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SpriteBaseURL is where the sprites referenced by the API are hosted.
+const SpriteBaseURL = "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/"
+
+// ParseResourceURL parses a resource URL, such as
+// "https://pokeapi.co/api/v2/pokemon-species/25/", into the kind of resource
+// and its ID. Relative URLs, URLs without a trailing slash and sub-resources
+// such as ".../pokemon/25/encounters" are accepted.
+func ParseResourceURL(rawURL string) (kind string, id int, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", 0, err
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		if id, err := strconv.Atoi(segments[i]); err == nil && id > 0 {
+			return segments[i-1], id, nil
+		}
+	}
+	return "", 0, fmt.Errorf("no resource ID in %q", rawURL)
+}
+
+// ID returns the ID of the referenced resource, parsed from its URL without
+// a request, or zero if the URL has none.
+func (n NamedURL) ID() int {
+	_, id, _ := ParseResourceURL(n.URL)
+	return id
+}
+
+// Kind returns the kind of the referenced resource, such as "pokemon-species",
+// parsed from its URL, or "" if the URL has no ID.
+func (n NamedURL) Kind() string {
+	kind, _, _ := ParseResourceURL(n.URL)
+	return kind
+}
+
+// ID returns the ID of the referenced resource, or zero if the URL has none.
+func (r APIResource) ID() int {
+	_, id, _ := ParseResourceURL(r.URL)
+	return id
+}
+
+// Kind returns the kind of the referenced resource, or "" if the URL has no ID.
+func (r APIResource) Kind() string {
+	kind, _, _ := ParseResourceURL(r.URL)
+	return kind
+}
+
+// Resolve gets the resource a reference points to, looked up by the ID in its
+// URL so that every reference to a resource shares one cache key. References
+// without an ID are looked up by name. A nil client uses DefaultClient.
+func Resolve[T Resource](ctx context.Context, c *Client, ref NamedURL) (T, error) {
+	var resource T
+	path, ok := ResourcePath[T]()
+	if !ok {
+		return resource, fmt.Errorf("no endpoint registered for %s", typeOf[T]())
+	}
+	kind, id, err := ParseResourceURL(ref.URL)
+	if err != nil {
+		return Get[T](ctx, c, ref.Name)
+	}
+	if kind != path {
+		return resource, fmt.Errorf("cannot resolve a %s reference as %s", kind, typeOf[T]())
+	}
+	return Get[T](ctx, c, strconv.Itoa(id))
+}
+
+// SpriteURL returns the default sprite of a Pokémon, Pokémon species or item
+// reference without fetching the resource.
+func SpriteURL(ref NamedURL) (string, error) {
+	switch kind := ref.Kind(); kind {
+	case "pokemon", "pokemon-species":
+		return fmt.Sprintf("%spokemon/%d.png", SpriteBaseURL, ref.ID()), nil
+	case "item":
+		if ref.Name == "" {
+			return "", fmt.Errorf("item reference %q has no name", ref.URL)
+		}
+		return SpriteBaseURL + "items/" + ref.Name + ".png", nil
+	default:
+		return "", fmt.Errorf("no sprites for %q references", ref.URL)
+	}
+}
@@ -0,0 +1,103 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourceURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		kind string
+		id   int
+		err  bool
+	}{
+		{url: "https://pokeapi.co/api/v2/pokemon-species/25/", kind: "pokemon-species", id: 25},
+		{url: "https://pokeapi.co/api/v2/pokemon-species/25", kind: "pokemon-species", id: 25},
+		{url: "/api/v2/pokemon/6/", kind: "pokemon", id: 6},
+		{url: "stat/1", kind: "stat", id: 1},
+		{url: "https://pokeapi.co/api/v2/pokemon/25/encounters", kind: "pokemon", id: 25},
+		{url: "https://pokeapi.co/api/v2/pokemon/?offset=20&limit=20", err: true},
+		{url: "https://pokeapi.co/api/v2/pokemon/pikachu/", err: true},
+		{url: "", err: true},
+		{url: "://bad", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			kind, id, err := ParseResourceURL(tt.url)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.kind, kind)
+			require.Equal(t, tt.id, id)
+		})
+	}
+}
+
+func TestReferenceIDAndKind(t *testing.T) {
+	species := NamedURL{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon-species/25/"}
+	require.Equal(t, 25, species.ID())
+	require.Equal(t, "pokemon-species", species.Kind())
+
+	unparsable := NamedURL{Name: "pikachu"}
+	require.Zero(t, unparsable.ID())
+	require.Empty(t, unparsable.Kind())
+
+	characteristic := APIResource{URL: "/api/v2/characteristic/7/"}
+	require.Equal(t, 7, characteristic.ID())
+	require.Equal(t, "characteristic", characteristic.Kind())
+}
+
+func TestSpriteURL(t *testing.T) {
+	tests := []struct {
+		ref      NamedURL
+		expected string
+		err      bool
+	}{
+		{ref: NamedURL{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon/25/"}, expected: SpriteBaseURL + "pokemon/25.png"},
+		{ref: NamedURL{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon-species/25/"}, expected: SpriteBaseURL + "pokemon/25.png"},
+		{ref: NamedURL{Name: "oran-berry", URL: "https://pokeapi.co/api/v2/item/132/"}, expected: SpriteBaseURL + "items/oran-berry.png"},
+		{ref: NamedURL{Name: "hp", URL: "https://pokeapi.co/api/v2/stat/1/"}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref.URL, func(t *testing.T) {
+			url, err := SpriteURL(tt.ref)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, url)
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		json.NewEncoder(w).Encode(Stat{ID: 6, Name: "speed"})
+	}))
+	defer server.Close()
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	stat, err := Resolve[Stat](context.Background(), client, NamedURL{Name: "speed", URL: "https://pokeapi.co/api/v2/stat/6/"})
+	require.NoError(t, err)
+	require.Equal(t, "speed", stat.Name)
+
+	_, err = Resolve[Stat](context.Background(), client, NamedURL{Name: "speed"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/stat/6", "/stat/speed"}, paths)
+
+	_, err = Resolve[Stat](context.Background(), client, NamedURL{Name: "hardy", URL: "https://pokeapi.co/api/v2/nature/1/"})
+	require.ErrorContains(t, err, "cannot resolve a nature reference as pokemon.Stat")
+}
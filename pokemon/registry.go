@@ -0,0 +1,97 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Resource is any model that can be retrieved with Get once its endpoint
+// path has been registered with Register.
+type Resource interface{}
+
+var registry = struct {
+	sync.RWMutex
+	paths map[reflect.Type]string
+}{paths: make(map[reflect.Type]string)}
+
+func init() {
+	Register[Pokemon]("pokemon")
+	Register[Nature]("nature")
+	Register[Stat]("stat")
+	Register[ContestType]("contest-type")
+	Register[ContestEffect]("contest-effect")
+	Register[SuperContestEffect]("super-contest-effect")
+	Register[BerryFlavor]("berry-flavor")
+	Register[PokeathlonStat]("pokeathlon-stat")
+	Register[MoveBattleStyle]("move-battle-style")
+	Register[Characteristic]("characteristic")
+	Register[PokemonForm]("pokemon-form")
+	Register[PokemonColor]("pokemon-color")
+	Register[PokemonShape]("pokemon-shape")
+	Register[PokemonHabitat]("pokemon-habitat")
+	Register[Language]("language")
+}
+
+// Register associates the resource type T with an endpoint path relative to
+// Client.Endpoint, such as "pokemon". Registering a type again replaces its
+// path, so custom endpoints on a mirror can be registered the same way.
+func Register[T Resource](path string) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.paths[typeOf[T]()] = strings.Trim(path, "/")
+}
+
+// ResourcePath returns the endpoint path registered for T.
+func ResourcePath[T Resource]() (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	path, ok := registry.paths[typeOf[T]()]
+	return path, ok
+}
+
+// RegisteredPaths returns the endpoint paths of every registered resource, sorted.
+func RegisteredPaths() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	paths := make([]string, 0, len(registry.paths))
+	for _, path := range registry.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Get retrieves a resource of type T by ID or name from the endpoint
+// registered for T. A nil client uses DefaultClient.
+func Get[T Resource](ctx context.Context, c *Client, idOrName string) (resource T, err error) {
+	if c == nil {
+		c = DefaultClient
+	}
+	ctx, end := c.hooks().StartCall(ctx, "pokemon.Get"+typeOf[T]().Name())
+	defer func() { end(err) }()
+	return get[T](ctx, c, idOrName)
+}
+
+// helper function to fetch the resource registered for T without starting a call
+func get[T Resource](ctx context.Context, c *Client, idOrName string) (T, error) {
+	var resource T
+	path, ok := ResourcePath[T]()
+	if !ok {
+		return resource, fmt.Errorf("no endpoint registered for %s", typeOf[T]())
+	}
+	if idOrName == "" {
+		return resource, errors.New("you must provide either an ID or a Name")
+	}
+	err := fetchAndUnmarshal(ctx, c, path+"/"+strings.ToLower(idOrName), &resource)
+	return resource, err
+}
+
+// helper function to get the reflect.Type of a type parameter
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,87 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mirrorBadge struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nature/hardy":
+			json.NewEncoder(w).Encode(Nature{ID: 1, Name: "hardy"})
+		case "/badge/boulder":
+			json.NewEncoder(w).Encode(mirrorBadge{ID: 1, Name: "boulder"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), Endpoint: server.URL}
+
+	t.Run("Registered model", func(t *testing.T) {
+		nature, err := Get[Nature](context.Background(), client, "Hardy")
+		require.NoError(t, err)
+		require.Equal(t, Nature{ID: 1, Name: "hardy"}, nature)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := Get[Nature](context.Background(), client, "unknown")
+		require.Error(t, err)
+	})
+
+	t.Run("Empty lookup value", func(t *testing.T) {
+		_, err := Get[Nature](context.Background(), client, "")
+		require.Error(t, err)
+	})
+
+	t.Run("Unregistered type", func(t *testing.T) {
+		_, err := Get[mirrorBadge](context.Background(), client, "boulder")
+		require.ErrorContains(t, err, "no endpoint registered")
+	})
+
+	t.Run("Custom registration", func(t *testing.T) {
+		registerForTest[mirrorBadge](t, "/badge/")
+		path, ok := ResourcePath[mirrorBadge]()
+		require.True(t, ok)
+		require.Equal(t, "badge", path)
+
+		badge, err := Get[mirrorBadge](context.Background(), client, "boulder")
+		require.NoError(t, err)
+		require.Equal(t, mirrorBadge{ID: 1, Name: "boulder"}, badge)
+	})
+
+	_, ok := ResourcePath[mirrorBadge]()
+	require.False(t, ok, "custom registration should be removed after the test")
+}
+
+// registerForTest registers T for the duration of the test, then restores
+// the registry so later tests don't see the entry.
+func registerForTest[T Resource](t *testing.T, path string) {
+	t.Helper()
+	registry.RLock()
+	previous, registered := registry.paths[typeOf[T]()]
+	registry.RUnlock()
+
+	Register[T](path)
+	t.Cleanup(func() {
+		registry.Lock()
+		defer registry.Unlock()
+		if registered {
+			registry.paths[typeOf[T]()] = previous
+		} else {
+			delete(registry.paths, typeOf[T]())
+		}
+	})
+}
@@ -0,0 +1,331 @@
+package pokemon
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SnapshotManifestFile is the name of the manifest inside a snapshot directory.
+const SnapshotManifestFile = "manifest.json"
+
+// snapshotSubResources lists the sub-resources fetched alongside each
+// resource of a path, e.g. pokemon/25/encounters for pokemon/25.
+var snapshotSubResources = map[string][]string{
+	"pokemon": {"encounters"},
+}
+
+// SnapshotManifest describes the contents of a snapshot directory.
+type SnapshotManifest struct {
+	Version   string    `json:"version"`
+	Endpoint  string    `json:"endpoint"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Complete is true once every path has been walked without error.
+	Complete bool `json:"complete"`
+	// Lists holds the results of every list endpoint, keyed by path.
+	Lists map[string][]NamedURL `json:"lists"`
+	// Resources is keyed by request path, such as "pokemon/25".
+	Resources map[string]SnapshotEntry `json:"resources"`
+}
+
+// SnapshotEntry records where a resource is stored and its checksum.
+type SnapshotEntry struct {
+	Name   string `json:"name,omitempty"`
+	File   string `json:"file"`
+	SHA256 string `json:"sha256"`
+}
+
+// SnapshotStats counts what a Snapshotter run did to each resource.
+type SnapshotStats struct {
+	Added     int
+	Updated   int
+	Unchanged int
+	Skipped   int
+	Removed   int
+}
+
+// Snapshotter downloads every resource of the API into a versioned directory
+// so it can be used without network access. Runs resume from the manifest:
+// resources already downloaded with a matching checksum are skipped.
+type Snapshotter struct {
+	// Client is used to list and fetch resources. Nil uses DefaultClient.
+	Client *Client
+	// Dir is the directory snapshots are written to, one subdirectory per version.
+	Dir string
+	// Version names the snapshot subdirectory. Defaults to "latest".
+	Version string
+	// Paths are the list endpoints to walk. Defaults to RegisteredPaths.
+	Paths []string
+	// PageSize is the number of results requested per list page. Defaults to 100.
+	PageSize int
+	// Refresh re-downloads resources already in the snapshot and rewrites those that changed.
+	Refresh bool
+}
+
+// Path returns the directory the snapshot is written to.
+func (s *Snapshotter) Path() string {
+	version := s.Version
+	if version == "" {
+		version = "latest"
+	}
+	return filepath.Join(s.Dir, version)
+}
+
+// Run walks every list endpoint and downloads the resources it lists. The
+// manifest is saved after each path, so an interrupted run can be resumed by
+// running again.
+func (s *Snapshotter) Run(ctx context.Context) (SnapshotStats, error) {
+	var stats SnapshotStats
+	client := s.Client
+	if client == nil {
+		client = DefaultClient
+	}
+	paths := s.Paths
+	if len(paths) == 0 {
+		paths = RegisteredPaths()
+	}
+
+	dir := s.Path()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return stats, err
+	}
+	manifest, err := ReadSnapshotManifest(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		manifest = SnapshotManifest{
+			Version:   filepath.Base(dir),
+			Endpoint:  client.Endpoint,
+			CreatedAt: time.Now().UTC(),
+		}
+	} else if err != nil {
+		return stats, err
+	}
+	if manifest.Lists == nil {
+		manifest.Lists = make(map[string][]NamedURL)
+	}
+	if manifest.Resources == nil {
+		manifest.Resources = make(map[string]SnapshotEntry)
+	}
+	manifest.Complete = false
+
+	for _, path := range paths {
+		err = s.snapshotPath(ctx, client, dir, strings.Trim(path, "/"), &manifest, &stats)
+		if saveErr := writeSnapshotManifest(dir, manifest); err == nil {
+			err = saveErr
+		}
+		if err != nil {
+			return stats, fmt.Errorf("error snapshotting %s: %w", path, err)
+		}
+	}
+
+	manifest.Complete = true
+	return stats, writeSnapshotManifest(dir, manifest)
+}
+
+// helper function to download every resource listed at path
+func (s *Snapshotter) snapshotPath(ctx context.Context, client *Client, dir, path string, manifest *SnapshotManifest, stats *SnapshotStats) error {
+	pageSize := s.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	var results []NamedURL
+	for offset := 0; ; offset += pageSize {
+		page, err := client.ListResources(ctx, path, ListOpts{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return err
+		}
+		results = append(results, page.Results...)
+		if page.Next == "" || len(page.Results) == 0 {
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, result := range results {
+		id := result.ID()
+		if id == 0 {
+			return fmt.Errorf("cannot find ID in %q", result.URL)
+		}
+		key := path + "/" + strconv.Itoa(id)
+		keys := []string{key}
+		for _, sub := range snapshotSubResources[path] {
+			keys = append(keys, key+"/"+sub)
+		}
+		for i, key := range keys {
+			seen[key] = true
+			name := ""
+			if i == 0 {
+				name = result.Name
+			}
+			if err := s.snapshotResource(ctx, client, dir, key, name, manifest, stats); err != nil {
+				return err
+			}
+		}
+	}
+	manifest.Lists[path] = results
+
+	// Drop resources the API no longer lists.
+	for key, entry := range manifest.Resources {
+		if strings.HasPrefix(key, path+"/") && !seen[key] {
+			if err := os.Remove(filepath.Join(dir, filepath.FromSlash(entry.File))); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			delete(manifest.Resources, key)
+			stats.Removed++
+		}
+	}
+	return nil
+}
+
+// helper function to download a single resource unless it can be skipped
+func (s *Snapshotter) snapshotResource(ctx context.Context, client *Client, dir, key, name string, manifest *SnapshotManifest, stats *SnapshotStats) error {
+	entry, exists := manifest.Resources[key]
+	if exists && !s.Refresh && fileMatches(filepath.Join(dir, filepath.FromSlash(entry.File)), entry.SHA256) {
+		stats.Skipped++
+		return nil
+	}
+
+	body, err := fetchBody(ctx, client, key)
+	if err != nil {
+		return err
+	}
+	sum := checksum(body)
+	file := filepath.ToSlash(key) + ".json"
+	target := filepath.Join(dir, filepath.FromSlash(file))
+
+	switch {
+	case !exists:
+		stats.Added++
+	case entry.SHA256 == sum && fileMatches(target, sum):
+		stats.Unchanged++
+		return nil
+	default:
+		stats.Updated++
+	}
+	if err := writeFileAtomic(target, body); err != nil {
+		return err
+	}
+	manifest.Resources[key] = SnapshotEntry{Name: name, File: file, SHA256: sum}
+	manifest.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// WriteArchive writes the snapshot directory to w as a gzip-compressed tar archive.
+func (s *Snapshotter) WriteArchive(w io.Writer) error {
+	dir := s.Path()
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadSnapshotManifest reads the manifest of the snapshot in dir.
+func ReadSnapshotManifest(dir string) (SnapshotManifest, error) {
+	var manifest SnapshotManifest
+	data, err := os.ReadFile(filepath.Join(dir, SnapshotManifestFile))
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(data, &manifest)
+	return manifest, err
+}
+
+// VerifySnapshot checks every file in the snapshot in dir against the
+// checksum in its manifest.
+func VerifySnapshot(dir string) error {
+	manifest, err := ReadSnapshotManifest(dir)
+	if err != nil {
+		return err
+	}
+	for key, entry := range manifest.Resources {
+		if !fileMatches(filepath.Join(dir, filepath.FromSlash(entry.File)), entry.SHA256) {
+			return fmt.Errorf("checksum mismatch for %s", key)
+		}
+	}
+	return nil
+}
+
+func writeSnapshotManifest(dir string, manifest SnapshotManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, SnapshotManifestFile), data)
+}
+
+// helper function to write a file via a temporary file so readers never see a partial write
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// helper function to check a file exists with the expected checksum
+func fileMatches(path, sum string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && checksum(data) == sum
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,167 @@
+package pokemon
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeListAPI serves list endpoints and resources from an in-memory map of
+// path to named resources, paginating with limit and offset.
+type fakeListAPI struct {
+	mu        sync.Mutex
+	resources map[string][]Nature
+}
+
+func (f *fakeListAPI) set(path string, resources ...Nature) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resources[path] = resources
+}
+
+func (f *fakeListAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	resources, ok := f.resources[parts[0]]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if len(parts) == 1 {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + limit
+		if end > len(resources) {
+			end = len(resources)
+		}
+		list := ResourceList{Count: len(resources)}
+		for _, resource := range resources[offset:end] {
+			list.Results = append(list.Results, NamedURL{Name: resource.Name, URL: fmt.Sprintf("http://%s/%s/%d/", r.Host, parts[0], resource.ID)})
+		}
+		if end < len(resources) {
+			list.Next = fmt.Sprintf("http://%s/%s/?limit=%d&offset=%d", r.Host, parts[0], limit, end)
+		}
+		json.NewEncoder(w).Encode(list)
+		return
+	}
+	for _, resource := range resources {
+		if strconv.Itoa(resource.ID) == parts[1] {
+			if len(parts) == 3 {
+				json.NewEncoder(w).Encode([]LocationAreaEncounter{})
+				return
+			}
+			json.NewEncoder(w).Encode(resource)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func TestSnapshotter(t *testing.T) {
+	api := &fakeListAPI{resources: map[string][]Nature{}}
+	api.set("nature", Nature{ID: 1, Name: "hardy"}, Nature{ID: 2, Name: "bold"}, Nature{ID: 3, Name: "modest"})
+	api.set("pokemon", Nature{ID: 25, Name: "pikachu"})
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	snapshotter := &Snapshotter{
+		Client:   &Client{HTTPClient: server.Client(), Endpoint: server.URL},
+		Dir:      t.TempDir(),
+		Version:  "v1",
+		Paths:    []string{"nature", "pokemon"},
+		PageSize: 2,
+	}
+
+	t.Run("Initial download", func(t *testing.T) {
+		stats, err := snapshotter.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, SnapshotStats{Added: 5}, stats)
+
+		manifest, err := ReadSnapshotManifest(snapshotter.Path())
+		require.NoError(t, err)
+		require.True(t, manifest.Complete)
+		require.Equal(t, "v1", manifest.Version)
+		require.Len(t, manifest.Lists["nature"], 3)
+		require.Equal(t, "hardy", manifest.Resources["nature/1"].Name)
+		require.Contains(t, manifest.Resources, "pokemon/25/encounters")
+		require.NoError(t, VerifySnapshot(snapshotter.Path()))
+
+		var nature Nature
+		data, err := os.ReadFile(filepath.Join(snapshotter.Path(), "nature", "2.json"))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &nature))
+		require.Equal(t, "bold", nature.Name)
+	})
+
+	t.Run("Resume skips downloaded resources and repairs corrupt ones", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(snapshotter.Path(), "nature", "3.json"), []byte("{}"), 0o644))
+		require.Error(t, VerifySnapshot(snapshotter.Path()))
+
+		stats, err := snapshotter.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, SnapshotStats{Skipped: 4, Updated: 1}, stats)
+		require.NoError(t, VerifySnapshot(snapshotter.Path()))
+	})
+
+	t.Run("Incremental refresh", func(t *testing.T) {
+		api.set("nature", Nature{ID: 1, Name: "hardy"}, Nature{ID: 2, Name: "bold", Names: LocalizedNames{{Name: "Bold"}}}, Nature{ID: 4, Name: "calm"})
+		snapshotter.Refresh = true
+		defer func() { snapshotter.Refresh = false }()
+
+		stats, err := snapshotter.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, SnapshotStats{Added: 1, Updated: 1, Unchanged: 3, Removed: 1}, stats)
+
+		_, err = os.Stat(filepath.Join(snapshotter.Path(), "nature", "3.json"))
+		require.True(t, os.IsNotExist(err))
+		require.NoError(t, VerifySnapshot(snapshotter.Path()))
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, snapshotter.WriteArchive(&buf))
+
+		gz, err := gzip.NewReader(&buf)
+		require.NoError(t, err)
+		tr := tar.NewReader(gz)
+		var names []string
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			names = append(names, header.Name)
+		}
+		require.Contains(t, names, SnapshotManifestFile)
+		require.Contains(t, names, "nature/4.json")
+		require.Contains(t, names, "pokemon/25/encounters.json")
+	})
+
+	t.Run("Failed path leaves a resumable manifest", func(t *testing.T) {
+		failing := *snapshotter
+		failing.Paths = []string{"nature", "unknown"}
+		_, err := failing.Run(context.Background())
+		require.Error(t, err)
+
+		manifest, err := ReadSnapshotManifest(snapshotter.Path())
+		require.NoError(t, err)
+		require.False(t, manifest.Complete)
+		require.Contains(t, manifest.Resources, "nature/4")
+	})
+}
@@ -0,0 +1,401 @@
+package pokemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNoSprite is returned when a Pokémon has no sprite for a variant.
+var ErrNoSprite = errors.New("no sprite")
+
+// SpriteSet holds the sprites of one style. Styles only provide some of them,
+// and missing sprites are empty.
+type SpriteSet struct {
+	FrontDefault     string `json:"front_default"`
+	FrontFemale      string `json:"front_female"`
+	FrontShiny       string `json:"front_shiny"`
+	FrontShinyFemale string `json:"front_shiny_female"`
+	BackDefault      string `json:"back_default"`
+	BackFemale       string `json:"back_female"`
+	BackShiny        string `json:"back_shiny"`
+	BackShinyFemale  string `json:"back_shiny_female"`
+}
+
+// OtherSprites holds the sprites of styles other than the default one.
+type OtherSprites struct {
+	DreamWorld      SpriteSet `json:"dream_world"`
+	Home            SpriteSet `json:"home"`
+	OfficialArtwork SpriteSet `json:"official-artwork"`
+	Showdown        SpriteSet `json:"showdown"`
+}
+
+// VersionSprites holds the sprites of one game. Besides the usual ones,
+// Generation I and II games have gray and transparent sprites and Generation
+// V games have animated ones.
+type VersionSprites struct {
+	SpriteSet
+	FrontGray             string    `json:"front_gray"`
+	BackGray              string    `json:"back_gray"`
+	FrontTransparent      string    `json:"front_transparent"`
+	BackTransparent       string    `json:"back_transparent"`
+	FrontShinyTransparent string    `json:"front_shiny_transparent"`
+	BackShinyTransparent  string    `json:"back_shiny_transparent"`
+	Animated              SpriteSet `json:"animated"`
+}
+
+// PokemonSprites holds every sprite of a Pokémon: the default sprites, other
+// styles such as official artwork, and the sprites of each game.
+type PokemonSprites struct {
+	SpriteSet
+	Other OtherSprites `json:"other"`
+	// Versions is keyed by generation and then version group, e.g.
+	// "generation-i" and "red-blue". Generations VII and VIII also have
+	// menu icons under the "icons" group.
+	Versions map[string]map[string]VersionSprites `json:"versions"`
+}
+
+// SpriteVariant addresses a sprite by its JSON path in PokemonSprites, e.g.
+// "front_shiny", "other/home/front_default",
+// "versions/generation-i/red-blue/front_gray" or
+// "versions/generation-v/black-white/animated/front_default".
+type SpriteVariant string
+
+// Common sprite variants.
+const (
+	SpriteFrontDefault         SpriteVariant = "front_default"
+	SpriteFrontShiny           SpriteVariant = "front_shiny"
+	SpriteFrontFemale          SpriteVariant = "front_female"
+	SpriteFrontShinyFemale     SpriteVariant = "front_shiny_female"
+	SpriteBackDefault          SpriteVariant = "back_default"
+	SpriteBackShiny            SpriteVariant = "back_shiny"
+	SpriteBackFemale           SpriteVariant = "back_female"
+	SpriteBackShinyFemale      SpriteVariant = "back_shiny_female"
+	SpriteOfficialArtwork      SpriteVariant = "other/official-artwork/front_default"
+	SpriteOfficialArtworkShiny SpriteVariant = "other/official-artwork/front_shiny"
+	SpriteHome                 SpriteVariant = "other/home/front_default"
+	SpriteHomeShiny            SpriteVariant = "other/home/front_shiny"
+	SpriteDreamWorld           SpriteVariant = "other/dream_world/front_default"
+	SpriteShowdown             SpriteVariant = "other/showdown/front_default"
+	SpriteShowdownShiny        SpriteVariant = "other/showdown/front_shiny"
+)
+
+// spriteFields lists the JSON names of the sprites in a SpriteSet.
+var spriteFields = []string{
+	"front_default", "front_female", "front_shiny", "front_shiny_female",
+	"back_default", "back_female", "back_shiny", "back_shiny_female",
+}
+
+// versionSpriteFields lists the JSON names of the sprites in a VersionSprites,
+// apart from its animated ones.
+var versionSpriteFields = append(append([]string{}, spriteFields...),
+	"front_gray", "back_gray", "front_transparent", "back_transparent",
+	"front_shiny_transparent", "back_shiny_transparent",
+)
+
+// otherStyles lists the JSON names of the styles in OtherSprites.
+var otherStyles = []string{"dream_world", "home", "official-artwork", "showdown"}
+
+// helper function to get a sprite of the set by its JSON name
+func (s SpriteSet) field(name string) string {
+	switch name {
+	case "front_default":
+		return s.FrontDefault
+	case "front_female":
+		return s.FrontFemale
+	case "front_shiny":
+		return s.FrontShiny
+	case "front_shiny_female":
+		return s.FrontShinyFemale
+	case "back_default":
+		return s.BackDefault
+	case "back_female":
+		return s.BackFemale
+	case "back_shiny":
+		return s.BackShiny
+	case "back_shiny_female":
+		return s.BackShinyFemale
+	}
+	return ""
+}
+
+// helper function to get a sprite of the game by its JSON name
+func (v VersionSprites) field(name string) string {
+	switch name {
+	case "front_gray":
+		return v.FrontGray
+	case "back_gray":
+		return v.BackGray
+	case "front_transparent":
+		return v.FrontTransparent
+	case "back_transparent":
+		return v.BackTransparent
+	case "front_shiny_transparent":
+		return v.FrontShinyTransparent
+	case "back_shiny_transparent":
+		return v.BackShinyTransparent
+	}
+	return v.SpriteSet.field(name)
+}
+
+// helper function to get a style of the other sprites by its JSON name
+func (o OtherSprites) style(name string) SpriteSet {
+	switch name {
+	case "dream_world":
+		return o.DreamWorld
+	case "home":
+		return o.Home
+	case "official-artwork":
+		return o.OfficialArtwork
+	case "showdown":
+		return o.Showdown
+	}
+	return SpriteSet{}
+}
+
+// URL returns the URL of a sprite variant, or "" if the Pokémon has none.
+func (s PokemonSprites) URL(variant SpriteVariant) string {
+	parts := strings.Split(string(variant), "/")
+	switch {
+	case len(parts) == 1:
+		return s.SpriteSet.field(parts[0])
+	case len(parts) == 3 && parts[0] == "other":
+		return s.Other.style(parts[1]).field(parts[2])
+	case len(parts) == 4 && parts[0] == "versions":
+		return s.Versions[parts[1]][parts[2]].field(parts[3])
+	case len(parts) == 5 && parts[0] == "versions" && parts[3] == "animated":
+		return s.Versions[parts[1]][parts[2]].Animated.field(parts[4])
+	}
+	return ""
+}
+
+// All returns the URL of every sprite the Pokémon has, keyed by variant.
+func (s PokemonSprites) All() map[SpriteVariant]string {
+	all := make(map[SpriteVariant]string)
+	add := func(prefix string, names []string, field func(string) string) {
+		for _, name := range names {
+			if u := field(name); u != "" {
+				all[SpriteVariant(prefix+name)] = u
+			}
+		}
+	}
+	add("", spriteFields, s.SpriteSet.field)
+	for _, style := range otherStyles {
+		add("other/"+style+"/", spriteFields, s.Other.style(style).field)
+	}
+	for generation, groups := range s.Versions {
+		for group, sprites := range groups {
+			prefix := "versions/" + generation + "/" + group + "/"
+			add(prefix, versionSpriteFields, sprites.field)
+			add(prefix+"animated/", spriteFields, sprites.Animated.field)
+		}
+	}
+	return all
+}
+
+// DownloadSprite writes a sprite of a Pokémon to w. It returns ErrNoSprite if
+// the Pokémon has no sprite for the variant.
+func (c *Client) DownloadSprite(ctx context.Context, pokemon Pokemon, variant SpriteVariant, w io.Writer) (err error) {
+	ctx, end := c.hooks().StartCall(ctx, "pokemon.DownloadSprite")
+	defer func() { end(err) }()
+
+	spriteURL := pokemon.Sprites.URL(variant)
+	if spriteURL == "" {
+		return fmt.Errorf("%w: %s has no %s sprite", ErrNoSprite, pokemon.Name, variant)
+	}
+	_, err = c.download(ctx, spriteURL, "sprite", w)
+	if err != nil {
+		return fmt.Errorf("error downloading %s sprite of %s: %w", variant, pokemon.Name, err)
+	}
+	return nil
+}
+
+// SpriteManifestFile is the name of the manifest inside a sprite directory.
+const SpriteManifestFile = "sprites.json"
+
+// SpriteManifest records the sprites synced into a directory.
+type SpriteManifest struct {
+	// Sprites is keyed by Pokémon name and variant, such as
+	// "pikachu/other/official-artwork/front_default".
+	Sprites map[string]SpriteEntry `json:"sprites"`
+}
+
+// SpriteEntry records where a sprite was downloaded from and where it is
+// stored. Files are named after their checksum, so sprites with the same
+// content share one file.
+type SpriteEntry struct {
+	URL    string `json:"url"`
+	File   string `json:"file"`
+	SHA256 string `json:"sha256"`
+}
+
+// SpriteSyncStats counts what a SpriteSync run did to each sprite.
+type SpriteSyncStats struct {
+	// Downloaded sprites were written to a new file.
+	Downloaded int
+	// Deduplicated sprites were downloaded but matched an existing file.
+	Deduplicated int
+	// Skipped sprites were already synced from the same URL.
+	Skipped int
+}
+
+// SpriteSync downloads sprites into a directory, storing each distinct image
+// once. Runs resume from the manifest: sprites already synced from the same
+// URL are skipped without a request.
+type SpriteSync struct {
+	// Client is used to fetch Pokémon and sprites. Nil uses DefaultClient.
+	Client *Client
+	// Dir is the directory sprites and the manifest are written to.
+	Dir string
+	// Pokemon are the names or IDs of the Pokémon to sync. Defaults to every
+	// Pokémon the API lists.
+	Pokemon []string
+	// Variants are the sprites to sync. Defaults to every sprite, including
+	// those of each game.
+	Variants []SpriteVariant
+}
+
+// Run downloads the sprites of every Pokémon. The manifest is saved after
+// each Pokémon, so an interrupted run can be resumed by running again.
+func (s *SpriteSync) Run(ctx context.Context) (SpriteSyncStats, error) {
+	var stats SpriteSyncStats
+	client := s.Client
+	if client == nil {
+		client = DefaultClient
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return stats, err
+	}
+	manifest, err := ReadSpriteManifest(s.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+	} else if err != nil {
+		return stats, err
+	}
+	if manifest.Sprites == nil {
+		manifest.Sprites = make(map[string]SpriteEntry)
+	}
+
+	names := s.Pokemon
+	if len(names) == 0 {
+		results, err := listAll(ctx, client, "pokemon")
+		if err != nil {
+			return stats, fmt.Errorf("error listing pokemon: %w", err)
+		}
+		for _, result := range results {
+			names = append(names, result.Name)
+		}
+	}
+
+	for _, name := range names {
+		err = s.syncPokemon(ctx, client, name, &manifest, &stats)
+		if saveErr := writeSpriteManifest(s.Dir, manifest); err == nil {
+			err = saveErr
+		}
+		if err != nil {
+			return stats, fmt.Errorf("error syncing sprites of %s: %w", name, err)
+		}
+	}
+	return stats, nil
+}
+
+// helper function to sync the sprites of a single Pokémon
+func (s *SpriteSync) syncPokemon(ctx context.Context, client *Client, name string, manifest *SpriteManifest, stats *SpriteSyncStats) error {
+	pokemon, err := Get[Pokemon](ctx, client, name)
+	if err != nil {
+		return err
+	}
+
+	sprites := pokemon.Sprites.All()
+	variants := s.Variants
+	if len(variants) == 0 {
+		for variant := range sprites {
+			variants = append(variants, variant)
+		}
+		sort.Slice(variants, func(i, j int) bool { return variants[i] < variants[j] })
+	}
+
+	for _, variant := range variants {
+		spriteURL, ok := sprites[variant]
+		if !ok {
+			continue
+		}
+		key := pokemon.Name + "/" + string(variant)
+		entry, exists := manifest.Sprites[key]
+		if exists && entry.URL == spriteURL && fileMatches(filepath.Join(s.Dir, filepath.FromSlash(entry.File)), entry.SHA256) {
+			stats.Skipped++
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := client.DownloadSprite(ctx, pokemon, variant, &buf); err != nil {
+			return err
+		}
+		sum := checksum(buf.Bytes())
+		file := path.Join(sum[:2], sum+spriteExt(spriteURL))
+		dest := filepath.Join(s.Dir, filepath.FromSlash(file))
+		if fileMatches(dest, sum) {
+			stats.Deduplicated++
+		} else {
+			if err := writeFileAtomic(dest, buf.Bytes()); err != nil {
+				return err
+			}
+			stats.Downloaded++
+		}
+		manifest.Sprites[key] = SpriteEntry{URL: spriteURL, File: file, SHA256: sum}
+	}
+	return nil
+}
+
+// ReadSpriteManifest reads the manifest of the sprite directory dir.
+func ReadSpriteManifest(dir string) (SpriteManifest, error) {
+	var manifest SpriteManifest
+	data, err := os.ReadFile(filepath.Join(dir, SpriteManifestFile))
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(data, &manifest)
+	return manifest, err
+}
+
+func writeSpriteManifest(dir string, manifest SpriteManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, SpriteManifestFile), data)
+}
+
+// helper function to list every resource at resourcePath, page by page
+func listAll(ctx context.Context, client *Client, resourcePath string) ([]NamedURL, error) {
+	const pageSize = 100
+	var results []NamedURL
+	for offset := 0; ; offset += pageSize {
+		page, err := client.ListResources(ctx, resourcePath, ListOpts{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return results, err
+		}
+		results = append(results, page.Results...)
+		if page.Next == "" || len(page.Results) == 0 {
+			return results, nil
+		}
+	}
+}
+
+// helper function to get the file extension of a sprite URL, such as ".png"
+func spriteExt(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		return path.Ext(u.Path)
+	}
+	return ""
+}
@@ -0,0 +1,212 @@
+package pokemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const spritesPayload = `{
+	"front_default": "%[1]s/sprites/25.png",
+	"front_shiny": "%[1]s/sprites/shiny/25.png",
+	"back_default": null,
+	"other": {
+		"official-artwork": {"front_default": "%[1]s/sprites/artwork/25.png", "front_shiny": null},
+		"home": {"front_default": "%[1]s/sprites/home/25.png"}
+	},
+	"versions": {
+		"generation-i": {"red-blue": {"front_default": "%[1]s/sprites/red-blue/25.png"}}
+	}
+}`
+
+// newSpriteServer serves pikachu, the pokemon list and its sprites. The
+// red-blue sprite has the same content as the default one.
+func newSpriteServer(t *testing.T, spriteRequests *atomic.Int32) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/pokemon" || r.URL.Path == "/pokemon/":
+			fmt.Fprintf(w, `{"count": 1, "results": [{"name": "pikachu", "url": "%s/pokemon/25/"}]}`, server.URL)
+		case r.URL.Path == "/pokemon/pikachu" || r.URL.Path == "/pokemon/25":
+			fmt.Fprintf(w, `{"id": 25, "name": "pikachu", "sprites": `+spritesPayload+`}`, server.URL)
+		case r.URL.Path == "/sprites/missing.png":
+			http.NotFound(w, r)
+		case strings.HasPrefix(r.URL.Path, "/sprites/"):
+			spriteRequests.Add(1)
+			image := strings.TrimPrefix(r.URL.Path, "/sprites/")
+			if image == "red-blue/25.png" {
+				image = "25.png"
+			}
+			fmt.Fprint(w, "png:"+image)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPokemonSprites(t *testing.T) {
+	var sprites PokemonSprites
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(spritesPayload, "https://sprites.test")), &sprites))
+
+	require.Equal(t, "https://sprites.test/sprites/25.png", sprites.FrontDefault)
+	require.Equal(t, "https://sprites.test/sprites/artwork/25.png", sprites.URL(SpriteOfficialArtwork))
+	require.Equal(t, "https://sprites.test/sprites/home/25.png", sprites.URL(SpriteHome))
+	require.Equal(t, "https://sprites.test/sprites/red-blue/25.png", sprites.URL("versions/generation-i/red-blue/front_default"))
+	require.Empty(t, sprites.URL(SpriteBackDefault))
+	require.Empty(t, sprites.URL(SpriteOfficialArtworkShiny))
+	require.Empty(t, sprites.URL("versions/generation-ii/gold/front_default"))
+	require.Empty(t, sprites.URL("other/unknown/front_default"))
+
+	require.Equal(t, map[SpriteVariant]string{
+		SpriteFrontDefault:    "https://sprites.test/sprites/25.png",
+		SpriteFrontShiny:      "https://sprites.test/sprites/shiny/25.png",
+		SpriteOfficialArtwork: "https://sprites.test/sprites/artwork/25.png",
+		SpriteHome:            "https://sprites.test/sprites/home/25.png",
+		"versions/generation-i/red-blue/front_default": "https://sprites.test/sprites/red-blue/25.png",
+	}, sprites.All())
+}
+
+func TestVersionSprites(t *testing.T) {
+	const payload = `{
+		"front_default": null,
+		"other": {},
+		"versions": {
+			"generation-i": {"yellow": {"front_default": "y.png", "front_gray": "y-gray.png", "back_transparent": "y-back-t.png"}},
+			"generation-ii": {"crystal": {"front_shiny_transparent": "c-shiny-t.png", "back_shiny_transparent": "c-back-shiny-t.png"}},
+			"generation-v": {"black-white": {"front_default": "bw.png", "animated": {"front_default": "bw.gif", "back_shiny": "bw-back-shiny.gif"}}},
+			"generation-vii": {"icons": {"front_default": "icon.png", "front_female": null}}
+		}
+	}`
+	var sprites PokemonSprites
+	require.NoError(t, json.Unmarshal([]byte(payload), &sprites))
+
+	require.Equal(t, "y-gray.png", sprites.Versions["generation-i"]["yellow"].FrontGray)
+	require.Equal(t, "bw.gif", sprites.Versions["generation-v"]["black-white"].Animated.FrontDefault)
+	require.Equal(t, "y-back-t.png", sprites.URL("versions/generation-i/yellow/back_transparent"))
+	require.Equal(t, "c-shiny-t.png", sprites.URL("versions/generation-ii/crystal/front_shiny_transparent"))
+	require.Equal(t, "bw-back-shiny.gif", sprites.URL("versions/generation-v/black-white/animated/back_shiny"))
+	require.Equal(t, "icon.png", sprites.URL("versions/generation-vii/icons/front_default"))
+	require.Empty(t, sprites.URL("versions/generation-v/black-white/animated/front_gray"))
+	require.Empty(t, sprites.URL("versions/generation-v/black-white/other/front_default"))
+
+	require.Equal(t, map[SpriteVariant]string{
+		"versions/generation-i/yellow/front_default":               "y.png",
+		"versions/generation-i/yellow/front_gray":                  "y-gray.png",
+		"versions/generation-i/yellow/back_transparent":            "y-back-t.png",
+		"versions/generation-ii/crystal/front_shiny_transparent":   "c-shiny-t.png",
+		"versions/generation-ii/crystal/back_shiny_transparent":    "c-back-shiny-t.png",
+		"versions/generation-v/black-white/front_default":          "bw.png",
+		"versions/generation-v/black-white/animated/front_default": "bw.gif",
+		"versions/generation-v/black-white/animated/back_shiny":    "bw-back-shiny.gif",
+		"versions/generation-vii/icons/front_default":              "icon.png",
+	}, sprites.All())
+}
+
+func TestDownloadSprite(t *testing.T) {
+	var spriteRequests atomic.Int32
+	server := newSpriteServer(t, &spriteRequests)
+	c := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/"}
+
+	pikachu, err := c.GetPokemon(context.Background(), GetPokemonOpts{Name: "pikachu"})
+	require.NoError(t, err)
+
+	t.Run("Writes the sprite", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, c.DownloadSprite(context.Background(), pikachu, SpriteOfficialArtwork, &buf))
+		require.Equal(t, "png:artwork/25.png", buf.String())
+	})
+
+	t.Run("Missing variant", func(t *testing.T) {
+		err := c.DownloadSprite(context.Background(), pikachu, SpriteBackDefault, &bytes.Buffer{})
+		require.ErrorIs(t, err, ErrNoSprite)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		missing := pikachu
+		missing.Sprites.FrontDefault = server.URL + "/sprites/missing.png"
+		err := c.DownloadSprite(context.Background(), missing, SpriteFrontDefault, &bytes.Buffer{})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		limited := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/", MaxBodySize: 4}
+		err := limited.DownloadSprite(context.Background(), pikachu, SpriteFrontDefault, &bytes.Buffer{})
+		require.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+}
+
+func TestDownloadSpriteHeaders(t *testing.T) {
+	var authorization atomic.Value
+	media := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		fmt.Fprint(w, "png")
+	}))
+	t.Cleanup(media.Close)
+	pikachu := Pokemon{Name: "pikachu"}
+	pikachu.Sprites.FrontDefault = media.URL + "/sprites/25.png"
+	auth := Headers(http.Header{"Authorization": {"Bearer secret"}})
+
+	t.Run("Other host", func(t *testing.T) {
+		c := &Client{HTTPClient: media.Client(), Endpoint: "https://mirror.example/api/v2/", Middleware: []Middleware{auth}}
+		require.NoError(t, c.DownloadSprite(context.Background(), pikachu, SpriteFrontDefault, &bytes.Buffer{}))
+		require.Empty(t, authorization.Load())
+	})
+
+	t.Run("Endpoint host", func(t *testing.T) {
+		c := &Client{HTTPClient: media.Client(), Endpoint: media.URL + "/", Middleware: []Middleware{auth}}
+		require.NoError(t, c.DownloadSprite(context.Background(), pikachu, SpriteFrontDefault, &bytes.Buffer{}))
+		require.Equal(t, "Bearer secret", authorization.Load())
+	})
+}
+
+func TestSpriteSync(t *testing.T) {
+	var spriteRequests atomic.Int32
+	server := newSpriteServer(t, &spriteRequests)
+	c := &Client{HTTPClient: server.Client(), Endpoint: server.URL + "/"}
+	dir := t.TempDir()
+	syncer := &SpriteSync{Client: c, Dir: dir}
+
+	stats, err := syncer.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, SpriteSyncStats{Downloaded: 4, Deduplicated: 1}, stats)
+	require.EqualValues(t, 5, spriteRequests.Load())
+
+	manifest, err := ReadSpriteManifest(dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Sprites, 5)
+	front := manifest.Sprites["pikachu/front_default"]
+	require.Equal(t, server.URL+"/sprites/25.png", front.URL)
+	require.Equal(t, front.File, manifest.Sprites["pikachu/versions/generation-i/red-blue/front_default"].File)
+	require.Equal(t, filepath.Join(front.SHA256[:2], front.SHA256+".png"), filepath.FromSlash(front.File))
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(front.File)))
+	require.NoError(t, err)
+	require.Equal(t, "png:25.png", string(data))
+
+	t.Run("Resumes from the manifest", func(t *testing.T) {
+		stats, err := syncer.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, SpriteSyncStats{Skipped: 5}, stats)
+		require.EqualValues(t, 5, spriteRequests.Load())
+	})
+
+	t.Run("Selected pokemon and variants", func(t *testing.T) {
+		spriteRequests.Store(0)
+		selected := &SpriteSync{Client: c, Dir: t.TempDir(), Pokemon: []string{"25"}, Variants: []SpriteVariant{SpriteOfficialArtwork, SpriteBackDefault}}
+		stats, err := selected.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, SpriteSyncStats{Downloaded: 1}, stats)
+		require.EqualValues(t, 1, spriteRequests.Load())
+	})
+}
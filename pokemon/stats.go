@@ -0,0 +1,262 @@
+package pokemon
+
+import (
+	"fmt"
+	"math"
+)
+
+// Names of the battle stats, as used by the API.
+const (
+	StatHP             = "hp"
+	StatAttack         = "attack"
+	StatDefense        = "defense"
+	StatSpecialAttack  = "special-attack"
+	StatSpecialDefense = "special-defense"
+	StatSpeed          = "speed"
+)
+
+// battleStats lists the battle stats in the order the API returns them.
+var battleStats = []string{StatHP, StatAttack, StatDefense, StatSpecialAttack, StatSpecialDefense, StatSpeed}
+
+const (
+	// MaxLevel is the highest level a Pokémon can reach.
+	MaxLevel = 100
+	// MaxEV is the most effort values a Pokémon can have in one stat.
+	MaxEV = 252
+	// MaxTotalEV is the most effort values a Pokémon can have across all stats.
+	MaxTotalEV = 510
+	// MaxDV is the highest value a Gen I/II determinant value can take.
+	MaxDV = 15
+	// MaxStatExp is the most stat experience a Gen I/II Pokémon can have in one stat.
+	MaxStatExp = 65535
+)
+
+// Get returns the value of a stat by its API name, such as "special-attack".
+func (s StatSet) Get(stat string) (int, bool) {
+	switch stat {
+	case StatHP:
+		return s.HP, true
+	case StatAttack:
+		return s.Attack, true
+	case StatDefense:
+		return s.Defense, true
+	case StatSpecialAttack:
+		return s.SpecialAttack, true
+	case StatSpecialDefense:
+		return s.SpecialDefense, true
+	case StatSpeed:
+		return s.Speed, true
+	}
+	return 0, false
+}
+
+// helper function to set the value of a stat by its API name
+func (s *StatSet) set(stat string, value int) bool {
+	switch stat {
+	case StatHP:
+		s.HP = value
+	case StatAttack:
+		s.Attack = value
+	case StatDefense:
+		s.Defense = value
+	case StatSpecialAttack:
+		s.SpecialAttack = value
+	case StatSpecialDefense:
+		s.SpecialDefense = value
+	case StatSpeed:
+		s.Speed = value
+	default:
+		return false
+	}
+	return true
+}
+
+// Total returns the sum of every stat.
+func (s StatSet) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpecialAttack + s.SpecialDefense + s.Speed
+}
+
+// BaseStats returns the base stats of a Pokémon, matched by the name of each
+// stat. It fails unless StatDetails has each of the six battle stats once.
+func (p Pokemon) BaseStats() (StatSet, error) {
+	var base StatSet
+	seen := make(map[string]bool)
+	for _, detail := range p.StatDetails {
+		stat := detail.StatInfo.Name
+		if seen[stat] {
+			return StatSet{}, fmt.Errorf("%s has stat %q more than once", p.Name, stat)
+		}
+		if !base.set(stat, detail.BaseStat) {
+			return StatSet{}, fmt.Errorf("%s has unknown stat %q", p.Name, stat)
+		}
+		seen[stat] = true
+	}
+	for _, stat := range battleStats {
+		if !seen[stat] {
+			return StatSet{}, fmt.Errorf("%s has no %s stat", p.Name, stat)
+		}
+	}
+	return base, nil
+}
+
+// StatFormula selects the formula used to calculate stats.
+type StatFormula int
+
+const (
+	// FormulaModern is the formula used since Generation III, with IVs, EVs
+	// and natures.
+	FormulaModern StatFormula = iota
+	// FormulaClassic is the formula of Generations I and II, with DVs and
+	// stat experience.
+	FormulaClassic
+)
+
+// CalculateStatsOpts contains options for CalculateStats function.
+type CalculateStatsOpts struct {
+	// Base is the Pokémon's base stats, e.g. from Pokemon.BaseStats.
+	Base StatSet
+	// Level is the Pokémon's level, from 1 to MaxLevel.
+	Level int
+	// IVs are the individual values, from 0 to MaxIV. With FormulaClassic
+	// they are DVs from 0 to MaxDV: the HP DV is derived from the others and
+	// the Special DV is read from SpecialAttack.
+	IVs StatSet
+	// EVs are the effort values, up to MaxEV each and MaxTotalEV in total.
+	// With FormulaClassic they are stat experience up to MaxStatExp each, and
+	// Special stat experience is read from SpecialAttack.
+	EVs StatSet
+	// Nature raises one stat by 10% and lowers another by 10%. The zero
+	// value and neutral natures change nothing. Ignored by FormulaClassic.
+	Nature Nature
+	// Formula selects the generation's formula. Defaults to FormulaModern.
+	Formula StatFormula
+}
+
+// CalculateStats returns the stats of a Pokémon as shown in game.
+func CalculateStats(opts CalculateStatsOpts) (StatSet, error) {
+	if opts.Level < 1 || opts.Level > MaxLevel {
+		return StatSet{}, fmt.Errorf("level %d is out of range 1-%d", opts.Level, MaxLevel)
+	}
+	for _, stat := range battleStats {
+		if base, _ := opts.Base.Get(stat); base < 1 || base > 255 {
+			return StatSet{}, fmt.Errorf("base %s %d is out of range 1-255", stat, base)
+		}
+	}
+	switch opts.Formula {
+	case FormulaModern:
+		return modernStats(opts)
+	case FormulaClassic:
+		return classicStats(opts)
+	default:
+		return StatSet{}, fmt.Errorf("unknown stat formula %d", opts.Formula)
+	}
+}
+
+// helper function to calculate stats with the Generation III onward formula
+func modernStats(opts CalculateStatsOpts) (StatSet, error) {
+	if err := validateModern(opts.IVs, opts.EVs); err != nil {
+		return StatSet{}, err
+	}
+
+	var stats StatSet
+	for _, stat := range battleStats {
+		base, _ := opts.Base.Get(stat)
+		iv, _ := opts.IVs.Get(stat)
+		ev, _ := opts.EVs.Get(stat)
+		value := (2*base + iv + ev/4) * opts.Level / 100
+		if stat == StatHP {
+			// Shedinja is the only Pokémon with a base HP of 1, and always has 1 HP
+			if base == 1 {
+				value = 1
+			} else {
+				value += opts.Level + 10
+			}
+		} else {
+			value = (value + 5) * natureModifier(opts.Nature, stat) / 100
+		}
+		stats.set(stat, value)
+	}
+	return stats, nil
+}
+
+// helper function to validate the IVs and EVs of the modern formula
+func validateModern(ivs, evs StatSet) error {
+	for _, stat := range battleStats {
+		if iv, _ := ivs.Get(stat); iv < 0 || iv > MaxIV {
+			return fmt.Errorf("%s IV %d is out of range 0-%d", stat, iv, MaxIV)
+		}
+		if ev, _ := evs.Get(stat); ev < 0 || ev > MaxEV {
+			return fmt.Errorf("%s EV %d is out of range 0-%d", stat, ev, MaxEV)
+		}
+	}
+	if total := evs.Total(); total > MaxTotalEV {
+		return fmt.Errorf("EVs total %d, more than %d", total, MaxTotalEV)
+	}
+	return nil
+}
+
+// helper function to calculate stats with the Generation I and II formula
+func classicStats(opts CalculateStatsOpts) (StatSet, error) {
+	dvs := opts.IVs
+	statExp := opts.EVs
+	dvs.SpecialDefense = dvs.SpecialAttack
+	statExp.SpecialDefense = statExp.SpecialAttack
+
+	for _, stat := range battleStats {
+		if dv, _ := dvs.Get(stat); dv < 0 || dv > MaxDV {
+			return StatSet{}, fmt.Errorf("%s DV %d is out of range 0-%d", stat, dv, MaxDV)
+		}
+		if exp, _ := statExp.Get(stat); exp < 0 || exp > MaxStatExp {
+			return StatSet{}, fmt.Errorf("%s stat experience %d is out of range 0-%d", stat, exp, MaxStatExp)
+		}
+	}
+
+	// The HP DV is made of the lowest bit of each other DV
+	hpDV := (dvs.Attack&1)<<3 | (dvs.Defense&1)<<2 | (dvs.Speed&1)<<1 | dvs.SpecialAttack&1
+	if dvs.HP != 0 && dvs.HP != hpDV {
+		return StatSet{}, fmt.Errorf("HP DV %d does not match the other DVs, which give %d", dvs.HP, hpDV)
+	}
+	dvs.HP = hpDV
+
+	var stats StatSet
+	for _, stat := range battleStats {
+		base, _ := opts.Base.Get(stat)
+		dv, _ := dvs.Get(stat)
+		exp, _ := statExp.Get(stat)
+		value := ((base+dv)*2 + statExpBonus(exp)) * opts.Level / 100
+		if stat == StatHP {
+			value += opts.Level + 10
+		} else {
+			value += 5
+		}
+		stats.set(stat, value)
+	}
+	return stats, nil
+}
+
+// helper function to get the bonus stat experience gives, a quarter of its
+// square root rounded up, capped at 255 like the games
+func statExpBonus(exp int) int {
+	root := int(math.Sqrt(float64(exp)))
+	for root*root > exp {
+		root--
+	}
+	if root*root < exp {
+		root++
+	}
+	return min(root, 255) / 4
+}
+
+// helper function to get the percentage a nature multiplies a stat by
+func natureModifier(nature Nature, stat string) int {
+	increased, decreased := nature.IncreasedStat.Name, nature.DecreasedStat.Name
+	switch {
+	case increased == decreased:
+		return 100
+	case stat == increased:
+		return 110
+	case stat == decreased:
+		return 90
+	}
+	return 100
+}
@@ -0,0 +1,142 @@
+package pokemon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var adamant = Nature{Name: "adamant", IncreasedStat: NamedURL{Name: StatAttack}, DecreasedStat: NamedURL{Name: StatSpecialAttack}}
+
+func TestBaseStats(t *testing.T) {
+	t.Run("From the API payload", func(t *testing.T) {
+		var pikachu Pokemon
+		require.NoError(t, json.Unmarshal([]byte(`{"name": "pikachu", "stats": [
+			{"base_stat": 35, "effort": 0, "stat": {"name": "hp", "url": "https://pokeapi.co/api/v2/stat/1/"}},
+			{"base_stat": 55, "effort": 0, "stat": {"name": "attack", "url": "https://pokeapi.co/api/v2/stat/2/"}},
+			{"base_stat": 40, "effort": 0, "stat": {"name": "defense", "url": "https://pokeapi.co/api/v2/stat/3/"}},
+			{"base_stat": 50, "effort": 0, "stat": {"name": "special-attack", "url": "https://pokeapi.co/api/v2/stat/4/"}},
+			{"base_stat": 50, "effort": 0, "stat": {"name": "special-defense", "url": "https://pokeapi.co/api/v2/stat/5/"}},
+			{"base_stat": 90, "effort": 2, "stat": {"name": "speed", "url": "https://pokeapi.co/api/v2/stat/6/"}}
+		]}`), &pikachu))
+		base, err := pikachu.BaseStats()
+		require.NoError(t, err)
+		require.Equal(t, StatSet{HP: 35, Attack: 55, Defense: 40, SpecialAttack: 50, SpecialDefense: 50, Speed: 90}, base)
+		require.Equal(t, 320, base.Total())
+	})
+
+	t.Run("In any order", func(t *testing.T) {
+		pokemon := Pokemon{StatDetails: statDetails(StatSpeed, 90, StatHP, 35, StatAttack, 55, StatDefense, 40, StatSpecialDefense, 50, StatSpecialAttack, 60)}
+		base, err := pokemon.BaseStats()
+		require.NoError(t, err)
+		require.Equal(t, StatSet{HP: 35, Attack: 55, Defense: 40, SpecialAttack: 60, SpecialDefense: 50, Speed: 90}, base)
+	})
+
+	tests := []struct {
+		scenario string
+		details  []StatDetails
+		err      string
+	}{
+		{scenario: "No stats", err: "no hp stat"},
+		{scenario: "Missing stat", details: statDetails(StatHP, 35, StatAttack, 55, StatDefense, 40, StatSpecialAttack, 50, StatSpecialDefense, 50), err: "no speed stat"},
+		{scenario: "Unnamed stats", details: []StatDetails{{BaseStat: 35}}, err: `unknown stat ""`},
+		{scenario: "Unknown stat", details: statDetails("accuracy", 1), err: `unknown stat "accuracy"`},
+		{scenario: "Duplicate stat", details: statDetails(StatHP, 35, StatHP, 40), err: `stat "hp" more than once`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			_, err := Pokemon{Name: "pikachu", StatDetails: tt.details}.BaseStats()
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+// statDetails builds StatDetails from pairs of stat names and base stats.
+func statDetails(pairs ...any) []StatDetails {
+	var details []StatDetails
+	for i := 0; i < len(pairs); i += 2 {
+		details = append(details, StatDetails{BaseStat: pairs[i+1].(int), StatInfo: NamedURL{Name: pairs[i].(string)}})
+	}
+	return details
+}
+
+func TestCalculateStats(t *testing.T) {
+	garchomp := StatSet{HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102}
+	mewtwo := StatSet{HP: 106, Attack: 110, Defense: 90, SpecialAttack: 154, SpecialDefense: 154, Speed: 130}
+	maxDVs := StatSet{Attack: 15, Defense: 15, SpecialAttack: 15, Speed: 15}
+	maxStatExp := StatSet{HP: MaxStatExp, Attack: MaxStatExp, Defense: MaxStatExp, SpecialAttack: MaxStatExp, Speed: MaxStatExp}
+
+	tests := []struct {
+		name     string
+		opts     CalculateStatsOpts
+		expected StatSet
+		err      bool
+	}{
+		{
+			name: "Modern formula with a nature",
+			opts: CalculateStatsOpts{
+				Base:   garchomp,
+				Level:  78,
+				IVs:    StatSet{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5},
+				EVs:    StatSet{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23},
+				Nature: adamant,
+			},
+			expected: StatSet{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171},
+		},
+		{
+			name:     "Modern formula with a neutral nature",
+			opts:     CalculateStatsOpts{Base: garchomp, Level: 50, Nature: Nature{Name: "hardy", IncreasedStat: NamedURL{Name: StatAttack}, DecreasedStat: NamedURL{Name: StatAttack}}},
+			expected: StatSet{HP: 168, Attack: 135, Defense: 100, SpecialAttack: 85, SpecialDefense: 90, Speed: 107},
+		},
+		{
+			name:     "Shedinja always has 1 HP",
+			opts:     CalculateStatsOpts{Base: StatSet{HP: 1, Attack: 90, Defense: 45, SpecialAttack: 30, SpecialDefense: 30, Speed: 40}, Level: 100, IVs: StatSet{HP: 31}, EVs: StatSet{HP: 252}},
+			expected: StatSet{HP: 1, Attack: 185, Defense: 95, SpecialAttack: 65, SpecialDefense: 65, Speed: 85},
+		},
+		{
+			name:     "Classic formula",
+			opts:     CalculateStatsOpts{Base: mewtwo, Level: 100, IVs: maxDVs, EVs: maxStatExp, Formula: FormulaClassic},
+			expected: StatSet{HP: 415, Attack: 318, Defense: 278, SpecialAttack: 406, SpecialDefense: 406, Speed: 358},
+		},
+		{
+			name:     "Classic formula ignores natures",
+			opts:     CalculateStatsOpts{Base: mewtwo, Level: 100, IVs: maxDVs, EVs: maxStatExp, Nature: adamant, Formula: FormulaClassic},
+			expected: StatSet{HP: 415, Attack: 318, Defense: 278, SpecialAttack: 406, SpecialDefense: 406, Speed: 358},
+		},
+		{
+			name:     "Classic formula derives the HP DV",
+			opts:     CalculateStatsOpts{Base: mewtwo, Level: 100, IVs: StatSet{HP: 10, Attack: 15, Defense: 14, SpecialAttack: 14, Speed: 15}, Formula: FormulaClassic},
+			expected: StatSet{HP: 342, Attack: 255, Defense: 213, SpecialAttack: 341, SpecialDefense: 341, Speed: 295},
+		},
+		{name: "Level too low", opts: CalculateStatsOpts{Base: garchomp}, err: true},
+		{name: "Level too high", opts: CalculateStatsOpts{Base: garchomp, Level: 101}, err: true},
+		{name: "Missing base stat", opts: CalculateStatsOpts{Base: StatSet{HP: 108}, Level: 50}, err: true},
+		{name: "IV too high", opts: CalculateStatsOpts{Base: garchomp, Level: 50, IVs: StatSet{Speed: 32}}, err: true},
+		{name: "Negative IV", opts: CalculateStatsOpts{Base: garchomp, Level: 50, IVs: StatSet{HP: -1}}, err: true},
+		{name: "EV too high", opts: CalculateStatsOpts{Base: garchomp, Level: 50, EVs: StatSet{Attack: 253}}, err: true},
+		{name: "EVs total too high", opts: CalculateStatsOpts{Base: garchomp, Level: 50, EVs: StatSet{HP: 252, Attack: 252, Speed: 7}}, err: true},
+		{name: "DV too high", opts: CalculateStatsOpts{Base: mewtwo, Level: 50, IVs: StatSet{Attack: 16}, Formula: FormulaClassic}, err: true},
+		{name: "Stat experience too high", opts: CalculateStatsOpts{Base: mewtwo, Level: 50, EVs: StatSet{Speed: MaxStatExp + 1}, Formula: FormulaClassic}, err: true},
+		{name: "HP DV does not match", opts: CalculateStatsOpts{Base: mewtwo, Level: 50, IVs: StatSet{HP: 15, Attack: 14}, Formula: FormulaClassic}, err: true},
+		{name: "Unknown formula", opts: CalculateStatsOpts{Base: garchomp, Level: 50, Formula: StatFormula(9)}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := CalculateStats(tt.opts)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, stats)
+		})
+	}
+}
+
+func TestStatExpBonus(t *testing.T) {
+	for exp, expected := range map[int]int{0: 0, 1: 0, 100: 2, 101: 2, 10000: 25, MaxStatExp: 63} {
+		require.Equal(t, expected, statExpBonus(exp), "stat experience %d", exp)
+	}
+}